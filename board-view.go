@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"lazyaz/internal/boards"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	cardStyle         = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(subtle).Padding(0, 1)
+	selectedCardStyle = cardStyle.BorderForeground(highlight)
+	columnHeaderStyle = lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	overLimitStyle    = columnHeaderStyle.Foreground(errorColor)
+)
+
+// cardHeight is the number of lines a card takes, borders included.
+const cardHeight = 4
+
+// BoardView shows the work items of an iteration in the columns of the team
+// board. Cards are moved between columns with < and >, which changes their
+// state and board column, within the WIP limits of the columns. The Doing and
+// Done halves of split columns are shown as one column, cards moved into one
+// landing in Doing.
+type BoardView struct {
+	board  boards.BoardMsg
+	column int
+	row    int
+}
+
+func newBoardView(msg boards.BoardMsg) *BoardView {
+	return &BoardView{board: msg}
+}
+
+// cards returns the work items in the column at index, in iteration order.
+func (b *BoardView) cards(index int) []workitemsmodels.WorkItem {
+	var cards []workitemsmodels.WorkItem
+	for _, item := range b.board.Items {
+		if b.board.Board.ColumnIndex(item) == index {
+			cards = append(cards, item)
+		}
+	}
+
+	return cards
+}
+
+// selected returns the card under the cursor.
+func (b *BoardView) selected() (workitemsmodels.WorkItem, bool) {
+	cards := b.cards(b.column)
+	if len(cards) == 0 {
+		return workitemsmodels.WorkItem{}, false
+	}
+
+	return cards[min(b.row, len(cards)-1)], true
+}
+
+func (b *BoardView) replace(item workitemsmodels.WorkItem) {
+	for index, card := range b.board.Items {
+		if card.ID == item.ID {
+			b.board.Items[index] = item
+			return
+		}
+	}
+}
+
+// focus moves the cursor to the column at index and the row of item in it.
+func (b *BoardView) focus(index int, item workitemsmodels.WorkItem) {
+	b.column = index
+	for row, card := range b.cards(index) {
+		if card.ID == item.ID {
+			b.row = row
+		}
+	}
+}
+
+// updateBoard handles the keys of the board tab, reporting whether key was one.
+func (m *Model) updateBoard(key tea.KeyMsg) (tea.Cmd, bool) {
+	b := m.board
+	if b == nil || len(b.board.Board.Columns) == 0 {
+		return nil, false
+	}
+
+	columns := len(b.board.Board.Columns)
+
+	switch key.String() {
+	case "left", "h":
+		b.column = (b.column - 1 + columns) % columns
+		b.row = min(b.row, max(0, len(b.cards(b.column))-1))
+	case "right", "l":
+		b.column = (b.column + 1) % columns
+		b.row = min(b.row, max(0, len(b.cards(b.column))-1))
+	case "up", "k":
+		b.row = max(0, b.row-1)
+	case "down", "j":
+		b.row = min(b.row+1, max(0, len(b.cards(b.column))-1))
+	case "<":
+		return m.moveCard(-1), true
+	case ">":
+		return m.moveCard(1), true
+	default:
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// moveCard moves the selected card to the next column in direction, setting
+// the state the column maps its type to.
+func (m *Model) moveCard(direction int) tea.Cmd {
+	b := m.board
+	item, ok := b.selected()
+	if !ok {
+		return nil
+	}
+
+	target := b.column + direction
+	if target < 0 || target >= len(b.board.Board.Columns) {
+		return nil
+	}
+
+	column := b.board.Board.Columns[target]
+	if count := len(b.cards(target)); column.OverLimit(count + 1) {
+		m.status = fmt.Sprintf("%s is at its WIP limit (%d/%d)", column.Name, count, column.ItemLimit)
+		return nil
+	}
+
+	state, ok := column.StateMappings[item.Fields.WorkItemType]
+	if !ok {
+		m.status = fmt.Sprintf("%s has no state for %s", column.Name, item.Fields.WorkItemType)
+		return nil
+	}
+
+	operations := workitems.ChangeState(state)
+	if field := b.board.Board.Fields.ColumnField.ReferenceName; field != "" {
+		operations = append(operations, workitems.SetField(field, column.Name))
+	}
+	if field := b.board.Board.Fields.DoneField.ReferenceName; field != "" && column.IsSplit {
+		operations = append(operations, workitems.SetField(field, false))
+	}
+
+	moved := item
+	moved.Fields.State, moved.Fields.BoardColumn = state, column.Name
+	m.replaceItem(moved)
+	b.focus(target, moved)
+	m.status = fmt.Sprintf("moving #%d to %s…", item.ID, column.Name)
+
+	return workitems.UpdateWorkItem(context.Background(), m.client, item, operations)
+}
+
+func (b *BoardView) View(width, height int) string {
+	columns := b.board.Board.Columns
+	if len(columns) == 0 {
+		return "The team board has no columns"
+	}
+
+	columnWidth := max(16, width/len(columns))
+	visible := max(1, (height-2)/cardHeight)
+
+	var rendered []string
+	for index, column := range columns {
+		cards := b.cards(index)
+
+		header := columnHeaderStyle.Render(column.Name)
+		if column.ItemLimit > 0 {
+			label := fmt.Sprintf("%s %d/%d", column.Name, len(cards), column.ItemLimit)
+			header = columnHeaderStyle.Render(label)
+			if column.OverLimit(len(cards)) {
+				header = overLimitStyle.Render(label)
+			}
+		}
+
+		lines := []string{header, ""}
+
+		start := 0
+		if index == b.column && b.row >= visible {
+			start = b.row - visible + 1
+		}
+
+		for row := start; row < min(len(cards), start+visible); row++ {
+			lines = append(lines, renderCard(cards[row], columnWidth-2, index == b.column && row == b.row))
+		}
+
+		if hidden := len(cards) - min(len(cards), start+visible); hidden > 0 {
+			lines = append(lines, helpStyle.Render(fmt.Sprintf(" +%d more", hidden)))
+		}
+
+		rendered = append(rendered, lipgloss.NewStyle().Width(columnWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func renderCard(item workitemsmodels.WorkItem, width int, selected bool) string {
+	style := cardStyle
+	if selected {
+		style = selectedCardStyle
+	}
+
+	inner := max(4, width-style.GetHorizontalFrameSize())
+	title := truncate(item.Title(), inner)
+	details := truncate(item.Fields.WorkItemType+" · "+item.Fields.AssignedTo.DisplayName, inner)
+
+	return style.Width(inner + style.GetHorizontalPadding()).Render(title + "\n" + helpStyle.Render(details))
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+
+	return strings.TrimSpace(string(runes[:max(0, width-1)])) + "…"
+}
+
+// current returns the item the actions apply to: the selected card on the
+// board tab, nothing on the burndown tab and the selected list item otherwise.
+func (m Model) current() list.Item {
+	if m.tabIndex == 3 {
+		return nil
+	}
+
+	if m.tabIndex == 2 {
+		if m.board == nil {
+			return nil
+		}
+
+		if item, ok := m.board.selected(); ok {
+			return item
+		}
+		return nil
+	}
+
+	return m.list.SelectedItem()
+}
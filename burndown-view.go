@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"lazyaz/internal/chart"
+	"lazyaz/internal/iterations"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	remainingStyle    = lipgloss.NewStyle().Foreground(highlight)
+	idealStyle        = lipgloss.NewStyle().Foreground(subtle)
+	capacityStyle     = lipgloss.NewStyle().Foreground(special)
+	overCapacityStyle = lipgloss.NewStyle().Foreground(errorColor)
+)
+
+const (
+	idealSeries = iota
+	remainingSeries
+)
+
+// BurndownView charts the remaining work of an iteration day by day against
+// the ideal trend, and compares the capacity left to each team member with
+// the remaining work assigned to them.
+type BurndownView struct {
+	burndown iterations.BurndownMsg
+	today    time.Time
+}
+
+func newBurndownView(msg iterations.BurndownMsg) *BurndownView {
+	return &BurndownView{burndown: msg, today: time.Now().UTC().Truncate(24 * time.Hour)}
+}
+
+func (v *BurndownView) View(width, height int) string {
+	iteration := v.burndown.Iteration
+	days := v.burndown.Schedule.Days(iteration)
+	if len(days) == 0 {
+		return fmt.Sprintf("%s has no start and finish dates", iteration.Name)
+	}
+
+	elapsed, left := 0, 0
+	for elapsed < len(days) && !days[elapsed].After(v.today) {
+		elapsed++
+	}
+	for left < len(days) && days[left].Before(v.today) {
+		left++
+	}
+
+	remaining := v.burndown.Remaining(days[:elapsed])
+
+	var current float64
+	for _, work := range v.burndown.AssignedWork() {
+		current += work
+	}
+
+	start := current
+	if len(remaining) > 0 {
+		start = remaining[0]
+	}
+
+	ideal := make([]float64, len(days))
+	for i := range ideal {
+		ideal[i] = start
+		if len(days) > 1 {
+			ideal[i] = start * float64(len(days)-1-i) / float64(len(days)-1)
+		}
+	}
+
+	top := max(1, slices.Max(ideal))
+	if len(remaining) > 0 {
+		top = max(top, slices.Max(remaining))
+	}
+
+	capacity := v.capacityView(days[left:], width)
+
+	label := formatHours(top)
+	chartWidth := max(10, width-len(label)-2)
+	chartHeight := max(4, height-lipgloss.Height(capacity)-6)
+
+	canvas := chart.NewCanvas(chartWidth, chartHeight)
+	canvas.Line(idealSeries, ideal, len(days), top)
+	canvas.Line(remainingSeries, remaining, len(days), top)
+
+	rows := canvas.Rows(func(series int, text string) string {
+		switch series {
+		case idealSeries:
+			return idealStyle.Render(text)
+		case remainingSeries:
+			return remainingStyle.Render(text)
+		}
+		return text
+	})
+
+	lines := []string{columnHeaderStyle.UnsetPadding().Render(fmt.Sprintf("%s · %s", iteration.Name, iteration.Dates())), ""}
+	for i, row := range rows {
+		axis := strings.Repeat(" ", len(label)) + " │"
+		switch i {
+		case 0:
+			axis = label + " ┤"
+		case len(rows) - 1:
+			axis = fmt.Sprintf("%*s ┤", len(label), "0h")
+		}
+		lines = append(lines, helpStyle.Render(axis)+row)
+	}
+
+	first, last := days[0].Format("Jan 2"), days[len(days)-1].Format("Jan 2")
+	lines = append(lines,
+		helpStyle.Render(strings.Repeat(" ", len(label))+" └"+strings.Repeat("─", chartWidth)),
+		helpStyle.Render(strings.Repeat(" ", len(label)+2)+first+strings.Repeat(" ", max(1, chartWidth-len(first)-len(last)))+last),
+		fmt.Sprintf("%s remaining  %s ideal  · %s left · %d of %d working days left",
+			remainingStyle.Render("━"), idealStyle.Render("━"), formatHours(current), len(days)-left, len(days)),
+		"",
+		capacity,
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// capacityView draws, for every team member, the remaining work assigned to
+// them against their capacity over days.
+func (v *BurndownView) capacityView(days []time.Time, width int) string {
+	type member struct {
+		name               string
+		capacity, assigned float64
+	}
+
+	assigned := v.burndown.AssignedWork()
+
+	var members []member
+	for _, capacity := range v.burndown.Capacities {
+		key := strings.ToLower(capacity.TeamMember.UniqueName)
+		members = append(members, member{capacity.TeamMember.DisplayName, capacity.Hours(days), assigned[key]})
+		delete(assigned, key)
+	}
+
+	names := make(map[string]string)
+	for _, item := range v.burndown.Items {
+		names[strings.ToLower(item.Fields.AssignedTo.UniqueName)] = item.Fields.AssignedTo.DisplayName
+	}
+
+	for key, work := range assigned {
+		if work == 0 {
+			continue
+		}
+
+		name := names[key]
+		if key == "" {
+			name = "Unassigned"
+		}
+		members = append(members, member{name: name, assigned: work})
+	}
+
+	if len(members) == 0 {
+		return helpStyle.Render("No capacity is planned for this iteration")
+	}
+
+	slices.SortFunc(members, func(a, b member) int { return strings.Compare(a.name, b.name) })
+
+	nameWidth, scale := 0, 1.0
+	for _, m := range members {
+		nameWidth = max(nameWidth, len([]rune(m.name)))
+		scale = max(scale, m.capacity, m.assigned)
+	}
+	nameWidth = min(nameWidth, 24)
+	barWidth := max(10, min(40, width-nameWidth-28))
+
+	lines := []string{columnHeaderStyle.UnsetPadding().Render("Capacity for the days left")}
+	for _, m := range members {
+		filled := int(math.Round(m.assigned / scale * float64(barWidth)))
+		available := int(math.Round(m.capacity / scale * float64(barWidth)))
+
+		var bar strings.Builder
+		for i := range barWidth {
+			switch {
+			case i < filled && i >= available:
+				bar.WriteString(overCapacityStyle.Render("█"))
+			case i < filled:
+				bar.WriteString(capacityStyle.Render("█"))
+			case i < available:
+				bar.WriteString(idealStyle.Render("░"))
+			default:
+				bar.WriteString(" ")
+			}
+		}
+
+		summary := fmt.Sprintf("%s / %s", formatHours(m.assigned), formatHours(m.capacity))
+		if m.assigned > m.capacity {
+			summary = overCapacityStyle.Render(summary)
+		}
+
+		name := lipgloss.NewStyle().Width(nameWidth).Render(truncate(m.name, nameWidth))
+		lines = append(lines, name+" "+bar.String()+" "+summary)
+	}
+
+	lines = append(lines, helpStyle.Render("assigned remaining work / capacity"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func formatHours(hours float64) string {
+	return fmt.Sprintf("%gh", math.Round(hours*10)/10)
+}
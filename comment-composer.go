@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mentionDelay is how long typing has to pause before people are looked up.
+const mentionDelay = 250 * time.Millisecond
+
+const minMentionQuery = 2
+
+type mentionQueryMsg string
+
+type commentSubmittedMsg struct {
+	Item     workitemsmodels.WorkItem
+	Text     string
+	Mentions []workitemsmodels.Identity
+}
+
+var selectedSuggestionStyle = lipgloss.NewStyle().Foreground(highlight).Bold(true)
+
+// CommentComposer writes a Markdown comment on a work item. Typing @ followed
+// by a name looks people up to mention them.
+type CommentComposer struct {
+	item        workitemsmodels.WorkItem
+	editor      textarea.Model
+	mentions    []workitemsmodels.Identity
+	query       string
+	suggestions []workitemsmodels.Identity
+	suggestion  int
+	searching   bool
+	noMatch     bool
+	err         error
+	search      func(query string) tea.Cmd
+}
+
+func newCommentComposer(item workitemsmodels.WorkItem, search func(query string) tea.Cmd) (CommentComposer, tea.Cmd) {
+	editor := textarea.New()
+	editor.SetWidth(70)
+	editor.SetHeight(8)
+	editor.ShowLineNumbers = false
+	editor.Placeholder = "Markdown comment, @ to mention someone"
+
+	c := CommentComposer{item: item, editor: editor, search: search}
+
+	return c, c.editor.Focus()
+}
+
+func (c CommentComposer) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case mentionQueryMsg:
+		if string(msg) != c.query {
+			return c, nil
+		}
+
+		c.searching = true
+		return c, c.search(c.query)
+
+	case workitems.IdentitiesMsg:
+		if msg.Query != c.query {
+			return c, nil
+		}
+
+		c.searching = false
+		c.err = msg.Err
+		c.suggestions = msg.Identities
+		c.suggestion = 0
+		c.noMatch = msg.Err == nil && len(msg.Identities) == 0
+		return c, nil
+
+	case tea.KeyMsg:
+		if len(c.suggestions) > 0 {
+			switch msg.String() {
+			case "ctrl+n", "down":
+				c.suggestion = (c.suggestion + 1) % len(c.suggestions)
+				return c, nil
+			case "ctrl+p", "up":
+				c.suggestion = (c.suggestion - 1 + len(c.suggestions)) % len(c.suggestions)
+				return c, nil
+			case "tab", "enter":
+				c.mention(c.suggestions[c.suggestion])
+				return c, nil
+			case "esc":
+				c.suggestions = nil
+				return c, nil
+			}
+		}
+
+		switch msg.String() {
+		case "esc":
+			return c, closeDialog
+		case "ctrl+s":
+			text := strings.TrimSpace(c.editor.Value())
+			if text == "" {
+				return c, nil
+			}
+
+			submitted := commentSubmittedMsg{Item: c.item, Text: text, Mentions: c.mentions}
+			return c, tea.Sequence(closeDialog, func() tea.Msg { return submitted })
+		}
+	}
+
+	var cmd tea.Cmd
+	c.editor, cmd = c.editor.Update(msg)
+
+	return c, tea.Batch(cmd, c.lookup())
+}
+
+// lookup schedules a search when the @mention being typed changed.
+func (c *CommentComposer) lookup() tea.Cmd {
+	_, query := mentionQuery(c.editor.Value())
+	if query == c.query {
+		return nil
+	}
+
+	c.query = query
+	c.suggestions = nil
+	c.searching = false
+	c.noMatch = false
+	c.err = nil
+	if len([]rune(query)) < minMentionQuery {
+		return nil
+	}
+
+	return tea.Tick(mentionDelay, func(time.Time) tea.Msg { return mentionQueryMsg(query) })
+}
+
+// mention replaces the @mention being typed with the name of identity.
+func (c *CommentComposer) mention(identity workitemsmodels.Identity) {
+	start, _ := mentionQuery(c.editor.Value())
+	c.editor.SetValue(c.editor.Value()[:start] + "@" + identity.DisplayName + " ")
+
+	c.query = ""
+	c.suggestions = nil
+
+	for _, mentioned := range c.mentions {
+		if mentioned.ID == identity.ID {
+			return
+		}
+	}
+	c.mentions = append(c.mentions, identity)
+}
+
+// mentionQuery returns the position of the @ starting the word at the end of
+// text and the rest of that word, or an empty query when it is not a mention.
+func mentionQuery(text string) (int, string) {
+	start := strings.LastIndex(text, "@")
+	if start < 0 {
+		return 0, ""
+	}
+
+	if start > 0 && !unicode.IsSpace(rune(text[start-1])) {
+		return 0, ""
+	}
+
+	query := text[start+1:]
+	if strings.IndexFunc(query, unicode.IsSpace) >= 0 {
+		return 0, ""
+	}
+
+	return start, query
+}
+
+func (c CommentComposer) View() string {
+	lines := []string{fmt.Sprintf("Comment on #%d %s", c.item.ID, c.item.Fields.Title), "", c.editor.View()}
+
+	switch {
+	case c.searching:
+		lines = append(lines, helpStyle.Render("Looking up @"+c.query+"…"))
+	case len(c.suggestions) > 0:
+		for i, identity := range c.suggestions {
+			line := fmt.Sprintf("  @%s  %s", identity.DisplayName, helpStyle.Render(identity.UniqueName))
+			if i == c.suggestion {
+				line = selectedSuggestionStyle.Render("› @"+identity.DisplayName) + "  " + helpStyle.Render(identity.UniqueName)
+			}
+			lines = append(lines, line)
+		}
+	case c.err != nil:
+		lines = append(lines, errorStyle.UnsetPadding().Width(70).Render(describeError(c.err)))
+	case c.noMatch:
+		lines = append(lines, helpStyle.Render("No one matches @"+c.query))
+	}
+
+	lines = append(lines, "")
+	if len(c.suggestions) > 0 {
+		lines = append(lines, helpStyle.Render("ctrl+n/ctrl+p choose · tab mention · esc dismiss"))
+	} else {
+		lines = append(lines, helpStyle.Render("ctrl+s post · esc cancel"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
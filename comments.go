@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// previewDelay is how long a work item has to stay selected before its
+// comments and links are loaded, so scrolling through the list does not fetch
+// them all.
+const previewDelay = 300 * time.Millisecond
+
+type loadPreviewMsg int
+
+// refreshPreview shows the selected item in the preview and schedules loading
+// its comments and links when they are not loaded yet.
+func (m *Model) refreshPreview() tea.Cmd {
+	m.renderPreview()
+
+	item, ok := m.list.SelectedItem().(workitemsmodels.WorkItem)
+	if !ok || m.previewPending == item.ID || (!m.needsComments(item) && !m.needsLinks(item)) {
+		return nil
+	}
+
+	m.previewPending = item.ID
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg { return loadPreviewMsg(item.ID) })
+}
+
+func (m *Model) needsComments(item workitemsmodels.WorkItem) bool {
+	_, loaded := m.comments[item.ID]
+	return item.Fields.CommentCount > 0 && !loaded
+}
+
+// needsLinks tells whether the relations of item are still to be loaded, the
+// list being loaded without them.
+func (m *Model) needsLinks(item workitemsmodels.WorkItem) bool {
+	_, loaded := m.links[item.ID]
+	return item.Relations == nil && !loaded
+}
+
+// renderPreview shows the selected item in the preview, with its links and
+// followed by its comments once they are loaded.
+func (m *Model) renderPreview() {
+	i, ok := m.list.SelectedItem().(models.UiItem)
+	if !ok {
+		return
+	}
+
+	m.selectedItem = i.GetID()
+	content := i.GetPreview(m.renderer)
+	if tagged, ok := i.(taggedItem); ok && len(tagged.TagList()) > 0 {
+		content = "\n  " + tagChips(tagged.TagList(), m.preview.Width-4) + "\n" + content
+	}
+
+	item, ok := i.(workitemsmodels.WorkItem)
+	if !ok {
+		m.preview.SetContent(content)
+		return
+	}
+
+	if links, loaded := m.links[item.ID]; loaded && links.Err != nil {
+		content += errorStyle.Render(describeError(links.Err)) + "\n"
+	}
+
+	if item.Fields.CommentCount == 0 {
+		m.preview.SetContent(content)
+		return
+	}
+
+	comments, loaded := m.comments[item.ID]
+	switch {
+	case !loaded:
+		m.preview.SetContent(content + helpStyle.Render(fmt.Sprintf("  %d comments, loading…", item.Fields.CommentCount)))
+		return
+	case comments.Err != nil:
+		m.preview.SetContent(content + errorStyle.Render(describeError(comments.Err)))
+		return
+	}
+
+	rendered, err := m.renderer.Render(workitemsmodels.CommentsMarkdown(comments.Comments, comments.TotalCount))
+	if err != nil {
+		rendered = err.Error()
+	}
+	m.preview.SetContent(content + rendered)
+}
+
+// loadPreview fetches the comments and links of id that are not loaded yet,
+// if it is still the selected item. id stays pending while they are fetched.
+func (m *Model) loadPreview(id int) tea.Cmd {
+	item, ok := m.list.SelectedItem().(workitemsmodels.WorkItem)
+	if !ok || item.ID != id {
+		if m.previewPending == id {
+			m.previewPending = 0
+		}
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if m.needsComments(item) {
+		cmds = append(cmds, workitems.FetchComments(m.fetchCtx, m.client, id))
+	}
+	if m.needsLinks(item) {
+		cmds = append(cmds, workitems.FetchPreviewLinks(m.fetchCtx, m.client, id))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// commentsLoaded keeps the comments of a work item, or the error loading
+// them, and shows them if it is selected. A failed load is not pending any
+// more, the error staying in the preview until the list is refreshed.
+func (m *Model) commentsLoaded(msg workitems.CommentsMsg) {
+	if m.previewCanceled(msg.WorkItemID, msg.Err) {
+		return
+	}
+
+	m.comments[msg.WorkItemID] = msg
+	if msg.Err != nil && m.previewPending == msg.WorkItemID {
+		m.previewPending = 0
+	}
+
+	if msg.WorkItemID == m.selectedItem {
+		m.renderPreview()
+	}
+}
+
+// previewCanceled tells whether err is a preview load cancelled by a refetch,
+// which is dropped rather than cached so the item is loaded again.
+func (m *Model) previewCanceled(id int, err error) bool {
+	if !errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if m.previewPending == id {
+		m.previewPending = 0
+	}
+
+	return true
+}
+
+// linksLoaded sets the relations loaded for the preview on the listed work item.
+func (m *Model) linksLoaded(msg workitems.PreviewLinksMsg) {
+	if m.previewCanceled(msg.ID, msg.Err) {
+		return
+	}
+
+	m.links[msg.ID] = msg
+	if msg.Err != nil {
+		if m.previewPending == msg.ID {
+			m.previewPending = 0
+		}
+		if msg.ID == m.selectedItem {
+			m.renderPreview()
+		}
+		return
+	}
+
+	for _, listed := range m.list.Items() {
+		if item, ok := listed.(workitemsmodels.WorkItem); ok && item.ID == msg.ID {
+			item.Relations = msg.Relations
+			m.replaceItem(item)
+			break
+		}
+	}
+}
+
+// commentPosted adds comment to the loaded comments of its work item and
+// counts it on the listed item.
+func (m *Model) commentPosted(comment workitemsmodels.Comment) {
+	if comments, loaded := m.comments[comment.WorkItemID]; loaded && comments.Err == nil {
+		comments.Comments = slices.Insert(comments.Comments, 0, comment)
+		comments.TotalCount++
+		m.comments[comment.WorkItemID] = comments
+	}
+
+	for _, listed := range m.list.Items() {
+		if item, ok := listed.(workitemsmodels.WorkItem); ok && item.ID == comment.WorkItemID {
+			item.Fields.CommentCount++
+			m.replaceItem(item)
+			break
+		}
+	}
+}
+
+// composeComment opens the comment composer on item, looking people up with
+// the current client.
+func (m Model) composeComment(item workitemsmodels.WorkItem) (Dialog, tea.Cmd) {
+	client := m.client
+
+	return newCommentComposer(item, func(query string) tea.Cmd {
+		return workitems.SearchIdentities(context.Background(), client, query)
+	})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	columnStyle  = lipgloss.NewStyle().Width(28).MaxWidth(28).PaddingRight(2)
+	changedStyle = columnStyle.Foreground(errorColor)
+	headerStyle  = columnStyle.Bold(true)
+)
+
+// ConflictDialog compares edits rejected because the work item changed on the
+// server with the current values, and lets the user overwrite or reload.
+type ConflictDialog struct {
+	conflict workitems.WorkItemConflictMsg
+}
+
+func (d ConflictDialog) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			return d, closeDialog
+		case "o":
+			overwrite := fieldsEditedMsg{Item: d.conflict.Current, Edits: d.conflict.Edits}
+			return d, tea.Sequence(closeDialog, func() tea.Msg { return overwrite })
+		case "r":
+			reload := workitems.WorkItemUpdatedMsg{Item: d.conflict.Current}
+			return d, tea.Sequence(closeDialog, func() tea.Msg { return reload })
+		}
+	}
+
+	return d, nil
+}
+
+func (d ConflictDialog) View() string {
+	original, current := d.conflict.Original, d.conflict.Current
+
+	rows := []string{
+		fmt.Sprintf("#%d was changed by %s (rev %d → %d)", current.ID, current.Fields.ChangedBy.DisplayName, original.Rev, current.Rev),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, headerStyle.Width(16).Render("Field"), headerStyle.Render("Yours"), headerStyle.Render("Server")),
+	}
+
+	for _, edit := range d.conflict.Edits {
+		serverValue := current.FieldValue(edit.ReferenceName)
+
+		server := columnStyle.Render(serverValue)
+		if serverValue != original.FieldValue(edit.ReferenceName) {
+			server = changedStyle.Render(serverValue)
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top,
+			labelStyle.Render(fieldLabel(edit.ReferenceName)),
+			columnStyle.Render(editValue(edit)),
+			server,
+		))
+	}
+
+	rows = append(rows, "", helpStyle.Render("o overwrite with yours · r reload server values · esc cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// editValue shows the value of edit as FieldValue shows the server value, the
+// description being sent as HTML but shown as Markdown.
+func editValue(edit workitems.FieldEdit) string {
+	if edit.ReferenceName != "System.Description" {
+		return fmt.Sprint(edit.Value)
+	}
+
+	html := fmt.Sprint(edit.Value)
+	edited := workitemsmodels.WorkItem{Fields: workitemsmodels.Fields{Description: &html}}
+
+	return edited.FieldValue(edit.ReferenceName)
+}
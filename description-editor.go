@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type descriptionEditedMsg struct {
+	Item     workitemsmodels.WorkItem
+	Original string
+	Path     string
+	Err      error
+}
+
+// editorCommand returns the user's editor from $VISUAL or $EDITOR, which may
+// carry arguments such as "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// editInExternalEditor writes content to a temporary Markdown file and
+// suspends the program while the user's editor is open on it. The file is
+// read back by the done callback.
+func editInExternalEditor(pattern, content string, done func(path string, err error) tea.Msg) tea.Cmd {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return func() tea.Msg { return done("", err) }
+	}
+
+	_, err = file.WriteString(content)
+	file.Close()
+	if err != nil {
+		os.Remove(file.Name())
+		return func() tea.Msg { return done("", err) }
+	}
+
+	return tea.ExecProcess(editorCommand(file.Name()), func(err error) tea.Msg {
+		return done(file.Name(), err)
+	})
+}
+
+// editDescription opens the description of item as Markdown in $EDITOR.
+func editDescription(item workitemsmodels.WorkItem) tea.Cmd {
+	markdown, err := item.DescriptionMarkdown()
+	if err != nil {
+		return func() tea.Msg {
+			return models.ErrorMsg{Action: "could not convert the description to Markdown", Err: err}
+		}
+	}
+
+	pattern := fmt.Sprintf("lazyaz-%d-*.md", item.ID)
+
+	return editInExternalEditor(pattern, markdown, func(path string, err error) tea.Msg {
+		return descriptionEditedMsg{Item: item, Original: markdown, Path: path, Err: err}
+	})
+}
+
+// saveDescription converts the edited Markdown back to HTML and submits it
+// when it changed.
+func (m *Model) saveDescription(msg descriptionEditedMsg) tea.Cmd {
+	if msg.Path != "" {
+		defer os.Remove(msg.Path)
+	}
+
+	if msg.Err != nil {
+		m.err = models.ErrorMsg{Action: "could not run the editor", Err: msg.Err}
+		return nil
+	}
+
+	data, err := os.ReadFile(msg.Path)
+	if err != nil {
+		m.err = models.ErrorMsg{Action: "could not read the edited description", Err: err}
+		return nil
+	}
+
+	markdown := string(data)
+	if strings.TrimSpace(markdown) == strings.TrimSpace(msg.Original) {
+		m.status = fmt.Sprintf("description of #%d unchanged", msg.Item.ID)
+		return nil
+	}
+
+	html, err := workitemsmodels.MarkdownToHtml(markdown)
+	if err != nil {
+		m.err = models.ErrorMsg{Action: "could not convert the description to HTML", Err: err}
+		return nil
+	}
+
+	m.status = fmt.Sprintf("saving the description of #%d…", msg.Item.ID)
+
+	return workitems.EditFields(context.Background(), m.client, msg.Item, []workitems.FieldEdit{{ReferenceName: "System.Description", Value: html}})
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Dialog is a modal component drawn over the list and preview panes. While a
+// dialog is open it receives every key press.
+type Dialog interface {
+	Update(msg tea.Msg) (Dialog, tea.Cmd)
+	View() string
+}
+
+type closeDialogMsg struct{}
+
+func closeDialog() tea.Msg {
+	return closeDialogMsg{}
+}
+
+var dialogStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(highlight).
+	Padding(1, 2)
+
+func renderDialog(d Dialog, width, height int) string {
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialogStyle.Render(d.View()))
+}
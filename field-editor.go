@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var editableFields = []struct {
+	Label         string
+	ReferenceName string
+}{
+	{"Title", "System.Title"},
+	{"Assigned To", "System.AssignedTo"},
+	{"Priority", "Microsoft.VSTS.Common.Priority"},
+	{"Iteration Path", "System.IterationPath"},
+	{"Area Path", "System.AreaPath"},
+	{"Tags", "System.Tags"},
+}
+
+// fieldLabels names fields that can be changed outside the field editor or
+// appear in the history.
+var fieldLabels = map[string]string{
+	"System.Description":                       "Description",
+	"System.State":                             "State",
+	"System.Reason":                            "Reason",
+	"System.History":                           "Comment",
+	"System.WorkItemType":                      "Type",
+	"System.CreatedBy":                         "Created By",
+	"System.CreatedDate":                       "Created Date",
+	"System.BoardColumn":                       "Board Column",
+	"Microsoft.VSTS.Common.AcceptanceCriteria": "Acceptance Criteria",
+	"Microsoft.VSTS.TCM.ReproSteps":            "Repro Steps",
+	"Microsoft.VSTS.Scheduling.RemainingWork":  "Remaining Work",
+	"Microsoft.VSTS.Scheduling.CompletedWork":  "Completed Work",
+	"Microsoft.VSTS.Scheduling.StoryPoints":    "Story Points",
+}
+
+func fieldLabel(referenceName string) string {
+	for _, field := range editableFields {
+		if field.ReferenceName == referenceName {
+			return field.Label
+		}
+	}
+
+	if label, ok := fieldLabels[referenceName]; ok {
+		return label
+	}
+
+	return referenceName
+}
+
+type fieldsEditedMsg struct {
+	Item  workitemsmodels.WorkItem
+	Edits []workitems.FieldEdit
+}
+
+// FieldEditor edits the common fields of a work item and submits the changed
+// ones only.
+type FieldEditor struct {
+	item workitemsmodels.WorkItem
+	form Form
+	err  error
+}
+
+func newFieldEditor(item workitemsmodels.WorkItem) (FieldEditor, tea.Cmd) {
+	var fields []FormField
+	for _, field := range editableFields {
+		fields = append(fields, NewFormField(field.Label, field.ReferenceName, item.FieldValue(field.ReferenceName)))
+	}
+
+	form, cmd := NewForm(fields...)
+
+	return FieldEditor{item: item, form: form}, cmd
+}
+
+func (e FieldEditor) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			return e, closeDialog
+		case "ctrl+s", "enter":
+			edits, err := e.edits()
+			if err != nil {
+				e.err = err
+				return e, nil
+			}
+
+			if len(edits) == 0 {
+				return e, closeDialog
+			}
+
+			edited := fieldsEditedMsg{Item: e.item, Edits: edits}
+			return e, tea.Sequence(closeDialog, func() tea.Msg { return edited })
+		}
+	}
+
+	var cmd tea.Cmd
+	e.form, cmd = e.form.Update(msg)
+
+	return e, cmd
+}
+
+func (e FieldEditor) edits() ([]workitems.FieldEdit, error) {
+	var edits []workitems.FieldEdit
+
+	for _, field := range e.form.Changed() {
+		var value any = field.Value()
+
+		switch field.ReferenceName {
+		case "Microsoft.VSTS.Common.Priority":
+			priority, err := strconv.Atoi(field.Value())
+			if err != nil {
+				return nil, fmt.Errorf("priority must be a number")
+			}
+			value = priority
+		case "System.Tags":
+			value = normalizeTags(field.Value())
+		}
+
+		edits = append(edits, workitems.FieldEdit{ReferenceName: field.ReferenceName, Value: value})
+	}
+
+	return edits, nil
+}
+
+func (e FieldEditor) View() string {
+	lines := []string{fmt.Sprintf("Edit #%d %s", e.item.ID, e.item.Fields.Title), "", e.form.View(), ""}
+
+	if e.err != nil {
+		lines = append(lines, errorStyle.UnsetPadding().Render(e.err.Error()))
+	}
+
+	lines = append(lines, helpStyle.Render("tab/shift+tab move · enter save · esc cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// normalizeTags turns a comma or semicolon separated list into the "a; b"
+// form System.Tags is stored in.
+func normalizeTags(value string) string {
+	var tags []string
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return strings.Join(tags, "; ")
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	labelStyle        = lipgloss.NewStyle().Width(16).Foreground(subtle)
+	focusedLabelStyle = labelStyle.Foreground(highlight).Bold(true)
+)
+
+type FormField struct {
+	Label         string
+	ReferenceName string
+	Initial       string
+	input         textinput.Model
+}
+
+func NewFormField(label, referenceName, value string) FormField {
+	input := textinput.New()
+	input.SetValue(value)
+	input.Width = 56
+	input.Prompt = ""
+
+	return FormField{Label: label, ReferenceName: referenceName, Initial: value, input: input}
+}
+
+// WithSuggestions completes the field from values as the user types, a
+// suggestion being accepted with the right arrow.
+func (f FormField) WithSuggestions(values []string) FormField {
+	f.input.ShowSuggestions = true
+	f.input.SetSuggestions(values)
+	f.input.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	f.input.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	f.input.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+
+	return f
+}
+
+func (f FormField) Value() string {
+	return strings.TrimSpace(f.input.Value())
+}
+
+// Form is a column of labelled text inputs navigated with tab and shift+tab.
+type Form struct {
+	Fields []FormField
+	focus  int
+}
+
+func NewForm(fields ...FormField) (Form, tea.Cmd) {
+	f := Form{Fields: fields}
+	if len(fields) == 0 {
+		return f, nil
+	}
+
+	return f, f.Fields[0].input.Focus()
+}
+
+func (f Form) Update(msg tea.Msg) (Form, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && len(f.Fields) > 0 {
+		switch msg.String() {
+		case "tab", "down":
+			return f, f.focusField((f.focus + 1) % len(f.Fields))
+		case "shift+tab", "up":
+			return f, f.focusField((f.focus - 1 + len(f.Fields)) % len(f.Fields))
+		}
+	}
+
+	if len(f.Fields) == 0 {
+		return f, nil
+	}
+
+	var cmd tea.Cmd
+	f.Fields[f.focus].input, cmd = f.Fields[f.focus].input.Update(msg)
+
+	return f, cmd
+}
+
+func (f *Form) focusField(index int) tea.Cmd {
+	f.Fields[f.focus].input.Blur()
+	f.focus = index
+
+	return f.Fields[f.focus].input.Focus()
+}
+
+// Focused returns the field being edited.
+func (f Form) Focused() *FormField {
+	if len(f.Fields) == 0 {
+		return nil
+	}
+
+	return &f.Fields[f.focus]
+}
+
+// Changed returns the fields whose value differs from their initial value.
+func (f Form) Changed() []FormField {
+	var changed []FormField
+	for _, field := range f.Fields {
+		if field.Value() != strings.TrimSpace(field.Initial) {
+			changed = append(changed, field)
+		}
+	}
+
+	return changed
+}
+
+func (f Form) View() string {
+	var rows []string
+	for i, field := range f.Fields {
+		label := labelStyle.Render(field.Label)
+		if i == f.focus {
+			label = focusedLabelStyle.Render(field.Label)
+		}
+
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, label, field.input.View()))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
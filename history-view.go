@@ -0,0 +1,179 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"lazyaz/internal/diff"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	revisionStyle = lipgloss.NewStyle().Foreground(highlight).Bold(true)
+	removedStyle  = lipgloss.NewStyle().Foreground(errorColor)
+	addedStyle    = lipgloss.NewStyle().Foreground(special)
+)
+
+// HistoryView lists the revisions of a work item, newest first, with the
+// before and after value of every field they changed.
+type HistoryView struct {
+	history   workitems.HistoryMsg
+	viewport  viewport.Model
+	stateOnly bool
+}
+
+func newHistoryView(history workitems.HistoryMsg, width, height int) HistoryView {
+	h := HistoryView{history: history, viewport: viewport.New(width, height)}
+	h.viewport.SetContent(h.render())
+
+	return h
+}
+
+func (h HistoryView) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			return h, closeDialog
+		case "s":
+			h.stateOnly = !h.stateOnly
+			h.viewport.SetContent(h.render())
+			h.viewport.GotoTop()
+			return h, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	h.viewport, cmd = h.viewport.Update(msg)
+
+	return h, cmd
+}
+
+func (h HistoryView) render() string {
+	var b strings.Builder
+
+	for _, update := range slices.Backward(h.history.Updates) {
+		if h.stateOnly {
+			if _, ok := update.Fields["System.State"]; !ok {
+				continue
+			}
+		}
+
+		fields := update.ChangedFields()
+		if len(fields) == 0 && update.Relations == nil {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %s\n", revisionStyle.Render(fmt.Sprintf("rev %d", update.Rev)), helpStyle.Render(update.RevisedBy.DisplayName+" · "+update.ChangedDate()))
+
+		slices.SortFunc(fields, compareFields)
+		for _, field := range fields {
+			renderFieldChange(&b, field, update.Fields[field])
+		}
+
+		if update.Relations != nil {
+			renderRelations(&b, update.Relations)
+		}
+
+		b.WriteString("\n")
+	}
+
+	if b.Len() == 0 {
+		return "No changes"
+	}
+
+	return b.String()
+}
+
+func renderFieldChange(b *strings.Builder, field string, change workitemsmodels.FieldChange) {
+	before := workitemsmodels.FormatValue(field, change.OldValue)
+	after := workitemsmodels.FormatValue(field, change.NewValue)
+
+	if field == "System.History" {
+		fmt.Fprintf(b, "  %s\n", labelStyle.Render(fieldLabel(field)))
+		for _, line := range strings.Split(after, "\n") {
+			fmt.Fprintf(b, "    %s\n", line)
+		}
+		return
+	}
+
+	if !workitemsmodels.IsRichText(field) && !strings.Contains(before+after, "\n") {
+		switch {
+		case before == "":
+			fmt.Fprintf(b, "  %s %s\n", labelStyle.Render(fieldLabel(field)), addedStyle.Render(after))
+		case after == "":
+			fmt.Fprintf(b, "  %s %s\n", labelStyle.Render(fieldLabel(field)), removedStyle.Render(before))
+		default:
+			fmt.Fprintf(b, "  %s %s → %s\n", labelStyle.Render(fieldLabel(field)), removedStyle.Render(before), addedStyle.Render(after))
+		}
+		return
+	}
+
+	fmt.Fprintf(b, "  %s\n", labelStyle.Render(fieldLabel(field)))
+	for _, line := range diff.Lines(before, after) {
+		switch line.Op {
+		case diff.Delete:
+			fmt.Fprintf(b, "    %s\n", removedStyle.Render("- "+line.Text))
+		case diff.Insert:
+			fmt.Fprintf(b, "    %s\n", addedStyle.Render("+ "+line.Text))
+		default:
+			fmt.Fprintf(b, "    %s\n", helpStyle.Render("  "+line.Text))
+		}
+	}
+}
+
+func renderRelations(b *strings.Builder, relations *workitemsmodels.RelationChanges) {
+	for _, relation := range relations.Added {
+		fmt.Fprintf(b, "  %s %s\n", labelStyle.Render("Link"), addedStyle.Render("+ "+describeRelation(relation)))
+	}
+
+	for _, relation := range relations.Removed {
+		fmt.Fprintf(b, "  %s %s\n", labelStyle.Render("Link"), removedStyle.Render("- "+describeRelation(relation)))
+	}
+
+	for _, relation := range relations.Updated {
+		fmt.Fprintf(b, "  %s %s\n", labelStyle.Render("Link"), "~ "+describeRelation(relation))
+	}
+}
+
+func describeRelation(relation workitemsmodels.Relation) string {
+	if id, ok := relation.WorkItemID(); ok {
+		return fmt.Sprintf("%s #%d", relation.Name(), id)
+	}
+
+	return relation.Name() + " " + relation.URL
+}
+
+// compareFields orders the state first and rich text fields last.
+func compareFields(a, b string) int {
+	rank := func(field string) int {
+		switch {
+		case field == "System.State":
+			return 0
+		case field == "System.Reason":
+			return 1
+		case workitemsmodels.IsRichText(field):
+			return 3
+		}
+		return 2
+	}
+
+	return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(fieldLabel(a), fieldLabel(b)))
+}
+
+func (h HistoryView) View() string {
+	item := h.history.Item
+	title := fmt.Sprintf("History of #%d %s", item.ID, item.Fields.Title)
+	if h.stateOnly {
+		title += " · state changes"
+	}
+
+	help := helpStyle.Render("↑/↓ scroll · s state changes only · esc close")
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", h.viewport.View(), "", help)
+}
@@ -0,0 +1,97 @@
+package boards
+
+import (
+	"context"
+	boards "lazyaz/internal/boards/models"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/iterations"
+	iterationsmodels "lazyaz/internal/iterations/models"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+// BoardMsg carries the requirement board of the team with the work items of
+// Iteration that appear on it.
+type BoardMsg struct {
+	Iteration iterationsmodels.Iteration
+	Board     boards.Board
+	Items     []workitemsmodels.WorkItem
+}
+
+type backlogConfiguration struct {
+	RequirementBacklog struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"requirementBacklog"`
+}
+
+// FetchBoard loads the board of the team's requirement backlog, such as
+// Stories or Backlog items, and the work items of iteration, or of the
+// current iteration when it is not set.
+func FetchBoard(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, iteration iterationsmodels.Iteration) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the board", Err: err}
+		}
+
+		var msg BoardMsg
+		group, groupCtx := errgroup.WithContext(ctx)
+
+		group.Go(func() (err error) {
+			msg.Board, err = fetchRequirementBoard(groupCtx, azHttpClient)
+			return err
+		})
+
+		group.Go(func() (err error) {
+			if iteration.ID == "" {
+				iteration, err = iterations.FetchCurrentIteration(groupCtx, azHttpClient)
+				if err != nil {
+					return err
+				}
+			}
+
+			ids, err := iterations.FetchIterationWorkItemIDs(groupCtx, azHttpClient, iteration.ID)
+			if err != nil {
+				return err
+			}
+
+			msg.Iteration = iteration
+			msg.Items, err = workitems.FetchWorkItemsByIds(groupCtx, azHttpClient, ids)
+			return err
+		})
+
+		if err := group.Wait(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the board", Err: err}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the board", Err: err}
+		}
+
+		var onBoard []workitemsmodels.WorkItem
+		for _, item := range msg.Items {
+			if msg.Board.Shows(item.Fields.WorkItemType) {
+				onBoard = append(onBoard, item)
+			}
+		}
+		msg.Items = onBoard
+
+		return msg
+	}
+}
+
+func fetchRequirementBoard(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient) (boards.Board, error) {
+	configuration, err := azhttpclient.Get[backlogConfiguration](ctx, azHttpClient, azHttpClient.TeamUrl("_apis/work/backlogconfiguration", nil))
+	if err != nil {
+		return boards.Board{}, err
+	}
+
+	boardUrl := azHttpClient.TeamUrl("_apis/work/boards/"+url.PathEscape(configuration.RequirementBacklog.Name), nil)
+
+	return azhttpclient.Get[boards.Board](ctx, azHttpClient, boardUrl)
+}
@@ -0,0 +1,70 @@
+package boards
+
+import workitems "lazyaz/internal/work-items/models"
+
+// Board is the Kanban board of a backlog level of the team.
+type Board struct {
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Columns []BoardColumn `json:"columns"`
+	Fields  BoardFields   `json:"fields"`
+}
+
+// BoardColumn is a column of the board. A split column has Doing and Done
+// halves, told apart by the done field of the board.
+type BoardColumn struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	ItemLimit     int               `json:"itemLimit"`
+	StateMappings map[string]string `json:"stateMappings"`
+	ColumnType    string            `json:"columnType"`
+	IsSplit       bool              `json:"isSplit"`
+}
+
+// BoardFields names the work item fields the board stores its column, row and
+// done flag in, specific to each team board.
+type BoardFields struct {
+	ColumnField FieldReference `json:"columnField"`
+	RowField    FieldReference `json:"rowField"`
+	DoneField   FieldReference `json:"doneField"`
+}
+
+type FieldReference struct {
+	ReferenceName string `json:"referenceName"`
+}
+
+// Shows tells whether work items of the type called workItemType appear on
+// the board.
+func (b Board) Shows(workItemType string) bool {
+	for _, column := range b.Columns {
+		if _, ok := column.StateMappings[workItemType]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ColumnIndex returns the column item is in: its board column when set, or the
+// first column mapped to its state.
+func (b Board) ColumnIndex(item workitems.WorkItem) int {
+	for index, column := range b.Columns {
+		if column.Name == item.Fields.BoardColumn {
+			return index
+		}
+	}
+
+	for index, column := range b.Columns {
+		if column.StateMappings[item.Fields.WorkItemType] == item.Fields.State {
+			return index
+		}
+	}
+
+	return 0
+}
+
+// OverLimit tells whether count items exceed the WIP limit of the column.
+// Incoming and outgoing columns have no limit.
+func (c BoardColumn) OverLimit(count int) bool {
+	return c.ItemLimit > 0 && c.ColumnType == "inProgress" && count > c.ItemLimit
+}
@@ -0,0 +1,122 @@
+// Package chart draws line charts with braille characters, each terminal cell
+// holding two columns of four dots.
+package chart
+
+import (
+	"math"
+	"strings"
+)
+
+// dotBits are the bits of the braille dots of a cell, by column and row.
+var dotBits = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Canvas is a grid of cells lines are plotted on. Each cell remembers the
+// last series drawn in it, so the series can be told apart by color.
+type Canvas struct {
+	width, height int
+	dots          [][]rune
+	series        [][]int
+}
+
+func NewCanvas(width, height int) *Canvas {
+	c := &Canvas{width: max(1, width), height: max(1, height)}
+	for range c.height {
+		c.dots = append(c.dots, make([]rune, c.width))
+
+		series := make([]int, c.width)
+		for i := range series {
+			series[i] = -1
+		}
+		c.series = append(c.series, series)
+	}
+
+	return c
+}
+
+// Line plots values as series index. The values are spread over points
+// evenly spaced columns, so a series shorter than points stops early. Values
+// are scaled from 0 at the bottom to top at the top row.
+func (c *Canvas) Line(index int, values []float64, points int, top float64) {
+	if len(values) == 0 || top <= 0 {
+		return
+	}
+
+	dotsWide, dotsHigh := c.width*2, c.height*4
+	step := 0.0
+	if points > 1 {
+		step = float64(dotsWide-1) / float64(points-1)
+	}
+
+	position := func(i int) (int, int) {
+		y := int(math.Round(min(values[i], top) / top * float64(dotsHigh-1)))
+		return int(math.Round(float64(i) * step)), dotsHigh - 1 - max(0, y)
+	}
+
+	x0, y0 := position(0)
+	c.set(index, x0, y0)
+
+	for i := 1; i < len(values); i++ {
+		x1, y1 := position(i)
+
+		steps := max(abs(x1-x0), abs(y1-y0))
+		for s := 1; s <= steps; s++ {
+			x := x0 + int(math.Round(float64((x1-x0)*s)/float64(steps)))
+			y := y0 + int(math.Round(float64((y1-y0)*s)/float64(steps)))
+			c.set(index, x, y)
+		}
+
+		x0, y0 = x1, y1
+	}
+}
+
+func (c *Canvas) set(index, x, y int) {
+	if x < 0 || y < 0 || x >= c.width*2 || y >= c.height*4 {
+		return
+	}
+
+	c.dots[y/4][x/2] |= dotBits[x%2][y%4]
+	c.series[y/4][x/2] = index
+}
+
+// Rows renders the canvas line by line, passing every run of cells of the
+// same series to style, -1 being the empty cells.
+func (c *Canvas) Rows(style func(series int, text string) string) []string {
+	var rows []string
+	for y := range c.height {
+		var row strings.Builder
+
+		start := 0
+		for x := 1; x <= c.width; x++ {
+			if x < c.width && c.series[y][x] == c.series[y][start] {
+				continue
+			}
+
+			var run strings.Builder
+			for _, dots := range c.dots[y][start:x] {
+				if dots == 0 {
+					run.WriteRune(' ')
+				} else {
+					run.WriteRune(0x2800 + dots)
+				}
+			}
+
+			row.WriteString(style(c.series[y][start], run.String()))
+			start = x
+		}
+
+		rows = append(rows, row.String())
+	}
+
+	return rows
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
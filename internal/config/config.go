@@ -0,0 +1,369 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultProfileName = "default"
+	DefaultApiVersion  = "7.1"
+	DefaultWiql        = `SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @Project AND [System.ChangedDate] >= @Today - 60 AND [System.AssignedTo] = @Me AND [System.WorkItemType] IN ('Task', 'User Story', 'Bug', 'Defect') ORDER BY [System.ChangedDate] DESC`
+)
+
+// Profile holds everything needed to talk to one organization and project.
+type Profile struct {
+	Name         string        `yaml:"name"`
+	Organization string        `yaml:"organization"`
+	Project      string        `yaml:"project"`
+	Team         string        `yaml:"team"`
+	Repositories []string      `yaml:"repositories"`
+	ApiVersion   string        `yaml:"api_version"`
+	Wiql         string        `yaml:"wiql"`
+	Queries      []Query       `yaml:"queries"`
+	PatEnv       string        `yaml:"pat_env"`
+	PatCommand   string        `yaml:"pat_command"`
+	Timeout      time.Duration `yaml:"timeout"`
+	Retry        Retry         `yaml:"retry"`
+}
+
+// Query is a named view of the Work Items tab, either a WIQL query or the id
+// of a saved query from the project query hierarchy.
+type Query struct {
+	Name string `yaml:"name"`
+	Wiql string `yaml:"wiql,omitempty"`
+	ID   string `yaml:"id,omitempty"`
+}
+
+// Retry configures how throttled (429/503) and failed idempotent requests are
+// retried. Durations use Go syntax, e.g. "500ms" or "30s".
+type Retry struct {
+	MaxAttempts int           `yaml:"max_attempts"`
+	BaseDelay   time.Duration `yaml:"base_delay"`
+	MaxDelay    time.Duration `yaml:"max_delay"`
+}
+
+// Config is the top level of the config file. Its inline Profile is the
+// default profile and the base every named profile inherits unset fields from.
+type Config struct {
+	Profile        `yaml:",inline"`
+	DefaultProfile string    `yaml:"default_profile"`
+	Profiles       []Profile `yaml:"profiles"`
+}
+
+// DefaultPath returns the location of the config file, honoring
+// LAZYAZ_CONFIG and XDG_CONFIG_HOME before falling back to ~/.config.
+func DefaultPath() string {
+	if path := os.Getenv("LAZYAZ_CONFIG"); path != "" {
+		return path
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".config", "lazyaz", "config.yaml")
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "lazyaz", "config.yaml")
+}
+
+// Load reads the config file at path and applies the AZURE_DEVOPS_* environment
+// overrides on top of it. A missing file is not an error.
+func Load(path string) (Config, error) {
+	cfg := Config{}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err == nil {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	cfg.applyEnv()
+	cfg.applyDefaults()
+
+	return cfg, nil
+}
+
+func (c *Config) applyEnv() {
+	if profile := os.Getenv("LAZYAZ_PROFILE"); profile != "" {
+		c.DefaultProfile = profile
+	}
+
+	if org := os.Getenv("AZURE_DEVOPS_ORG"); org != "" {
+		c.Organization = org
+	}
+
+	if project := os.Getenv("AZURE_DEVOPS_PROJECT"); project != "" {
+		c.Project = project
+	}
+
+	if team := os.Getenv("AZURE_DEVOPS_TEAM"); team != "" {
+		c.Team = team
+	}
+
+	if repositories := os.Getenv("AZURE_DEVOPS_REPOSITORIES"); repositories != "" {
+		c.Repositories = SplitList(repositories)
+	}
+
+	if apiVersion := os.Getenv("AZURE_DEVOPS_API_VERSION"); apiVersion != "" {
+		c.ApiVersion = apiVersion
+	}
+
+	if wiql := os.Getenv("AZURE_DEVOPS_WIQL"); wiql != "" {
+		c.Wiql = wiql
+	}
+}
+
+func (c *Config) applyDefaults() {
+	if c.Name == "" {
+		c.Name = DefaultProfileName
+	}
+
+	c.Organization = strings.TrimRight(c.Organization, "/")
+
+	if c.ApiVersion == "" {
+		c.ApiVersion = DefaultApiVersion
+	}
+
+	if c.Wiql == "" {
+		c.Wiql = DefaultWiql
+	}
+
+	if c.PatEnv == "" && c.PatCommand == "" {
+		c.PatEnv = "AZURE_DEVOPS_PAT"
+	}
+
+	if c.Timeout == 0 {
+		c.Timeout = 30 * time.Second
+	}
+
+	if c.Retry.MaxAttempts == 0 {
+		c.Retry.MaxAttempts = 4
+	}
+
+	if c.Retry.BaseDelay == 0 {
+		c.Retry.BaseDelay = time.Second
+	}
+
+	if c.Retry.MaxDelay == 0 {
+		c.Retry.MaxDelay = 30 * time.Second
+	}
+}
+
+// AllProfiles returns the default profile followed by every named profile, the
+// latter completed with the default profile's values. The default profile is
+// left out when only named profiles carry an organization.
+func (c Config) AllProfiles() []Profile {
+	var profiles []Profile
+
+	if c.Organization != "" || len(c.Profiles) == 0 {
+		profiles = append(profiles, c.Profile)
+	}
+
+	for _, profile := range c.Profiles {
+		profiles = append(profiles, profile.inherit(c.Profile))
+	}
+
+	return profiles
+}
+
+// ActiveProfile returns the profile called name, falling back to the
+// configured default profile and then to the first profile when name is empty.
+func (c Config) ActiveProfile(name string) (Profile, error) {
+	profiles := c.AllProfiles()
+
+	if name == "" {
+		name = c.DefaultProfile
+	}
+
+	if name == "" {
+		return profiles[0], nil
+	}
+
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return Profile{}, fmt.Errorf("profile %q not found in %s", name, DefaultPath())
+}
+
+// Override returns a copy of c with every non-empty field of overrides applied
+// to the default profile, used for command line flags which take precedence
+// over the file and env.
+func (c Config) Override(overrides Profile) Config {
+	if overrides.Organization != "" {
+		c.Organization = strings.TrimRight(overrides.Organization, "/")
+	}
+
+	if overrides.Project != "" {
+		c.Project = overrides.Project
+	}
+
+	if overrides.Team != "" {
+		c.Team = overrides.Team
+	}
+
+	if len(overrides.Repositories) > 0 {
+		c.Repositories = overrides.Repositories
+	}
+
+	if overrides.ApiVersion != "" {
+		c.ApiVersion = overrides.ApiVersion
+	}
+
+	if overrides.Wiql != "" {
+		c.Wiql = overrides.Wiql
+	}
+
+	return c
+}
+
+// Views returns the queries selectable on the Work Items tab, starting with
+// the profile's default wiql.
+func (p Profile) Views() []Query {
+	views := []Query{{Name: "Default", Wiql: p.Wiql}}
+
+	for _, query := range p.Queries {
+		if query.Wiql != "" || query.ID != "" {
+			views = append(views, query)
+		}
+	}
+
+	return views
+}
+
+// TeamName returns the team whose iterations and board are shown, by default
+// the one Azure DevOps creates with the project.
+func (p Profile) TeamName() string {
+	if p.Team != "" {
+		return p.Team
+	}
+
+	return p.Project + " Team"
+}
+
+// Validate reports the settings that must be present before any request is made.
+func (p Profile) Validate() error {
+	if p.Organization == "" {
+		return fmt.Errorf("profile %q has no organization, set it in %s or AZURE_DEVOPS_ORG", p.Name, DefaultPath())
+	}
+
+	if p.Project == "" {
+		return fmt.Errorf("profile %q has no project, set it in %s or AZURE_DEVOPS_PROJECT", p.Name, DefaultPath())
+	}
+
+	return nil
+}
+
+// Pat resolves the personal access token of the profile, running pat_command
+// when set and reading the pat_env variable otherwise.
+func (p Profile) Pat() (string, error) {
+	if p.PatCommand != "" {
+		out, err := exec.Command("sh", "-c", p.PatCommand).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to run pat_command of profile %q: %w", p.Name, err)
+		}
+
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	return os.Getenv(p.PatEnv), nil
+}
+
+// PatSource describes where the PAT of the profile comes from, for error messages.
+func (p Profile) PatSource() string {
+	if p.PatCommand != "" {
+		return fmt.Sprintf("the output of %q", p.PatCommand)
+	}
+
+	return fmt.Sprintf("the %s environment variable", p.PatEnv)
+}
+
+func (p Profile) inherit(base Profile) Profile {
+	if p.Name == "" {
+		p.Name = p.Project
+	}
+
+	if p.Organization == "" {
+		p.Organization = base.Organization
+	}
+
+	p.Organization = strings.TrimRight(p.Organization, "/")
+
+	if p.Project == "" {
+		p.Project = base.Project
+	}
+
+	if p.Team == "" && p.Project == base.Project {
+		p.Team = base.Team
+	}
+
+	if len(p.Repositories) == 0 && p.Project == base.Project {
+		p.Repositories = base.Repositories
+	}
+
+	if p.ApiVersion == "" {
+		p.ApiVersion = base.ApiVersion
+	}
+
+	if p.Wiql == "" {
+		p.Wiql = base.Wiql
+	}
+
+	if len(p.Queries) == 0 && p.Project == base.Project {
+		p.Queries = base.Queries
+	}
+
+	if p.PatEnv == "" && p.PatCommand == "" {
+		p.PatEnv = base.PatEnv
+		p.PatCommand = base.PatCommand
+	}
+
+	if p.Timeout == 0 {
+		p.Timeout = base.Timeout
+	}
+
+	if p.Retry.MaxAttempts == 0 {
+		p.Retry.MaxAttempts = base.Retry.MaxAttempts
+	}
+
+	if p.Retry.BaseDelay == 0 {
+		p.Retry.BaseDelay = base.Retry.BaseDelay
+	}
+
+	if p.Retry.MaxDelay == 0 {
+		p.Retry.MaxDelay = base.Retry.MaxDelay
+	}
+
+	return p
+}
+
+// SplitList splits a comma separated list, dropping blank entries.
+func SplitList(value string) []string {
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
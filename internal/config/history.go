@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const maxHistory = 50
+
+// HistoryPath returns the file keeping the WIQL queries run from the editor,
+// under XDG_STATE_HOME or ~/.local/state.
+func HistoryPath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(".local", "state", "lazyaz", "wiql-history.json")
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(dir, "lazyaz", "wiql-history.json")
+}
+
+// LoadHistory returns the queries run from the editor, most recent first.
+func LoadHistory() ([]string, error) {
+	data, err := os.ReadFile(HistoryPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query history: %w", err)
+	}
+
+	var history []string
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse query history: %w", err)
+	}
+
+	return history, nil
+}
+
+// AddToHistory moves wiql to the front of the history, dropping the oldest
+// entries past maxHistory.
+func AddToHistory(wiql string) error {
+	history, err := LoadHistory()
+	if err != nil {
+		return err
+	}
+
+	history = slices.DeleteFunc(history, func(entry string) bool { return entry == wiql })
+	history = append([]string{wiql}, history...)
+	history = history[:min(len(history), maxHistory)]
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode query history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(HistoryPath()), 0o755); err != nil {
+		return fmt.Errorf("failed to create the state directory: %w", err)
+	}
+
+	if err := os.WriteFile(HistoryPath(), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write query history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SaveQuery adds query to the queries of the profile called profileName in the
+// config file at path, creating the file if needed. The file is edited as a
+// YAML document so existing comments and ordering are kept. A profile still
+// inheriting the queries of the default profile gets a copy of them first, so
+// saving a view does not hide the ones it showed until then.
+func SaveQuery(path string, profileName string, query Query) error {
+	var document yaml.Node
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s is not a mapping", path)
+	}
+
+	profile := root
+	if profiles := mappingValue(root, "profiles"); profiles != nil && profiles.Kind == yaml.SequenceNode {
+		for _, candidate := range profiles.Content {
+			if scalarValue(candidate, "name", scalarValue(candidate, "project", "")) == profileName {
+				profile = candidate
+			}
+		}
+	}
+
+	var entry yaml.Node
+	if err := entry.Encode(query); err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	queries := mappingValue(profile, "queries")
+	if queries == nil {
+		queries = &yaml.Node{Kind: yaml.SequenceNode}
+		if inherited := mappingValue(root, "queries"); profile != root && inherited != nil && inheritsQueries(profile, root) {
+			queries.Content = slices.Clone(inherited.Content)
+		}
+		profile.Content = append(profile.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "queries"}, queries)
+	}
+	queries.Content = append(queries.Content, &entry)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the config directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// AddQuery records query on the profile called profileName, mirroring
+// SaveQuery for the config already loaded in memory.
+func (c *Config) AddQuery(profileName string, query Query) {
+	for i := range c.Profiles {
+		if inherited := c.Profiles[i].inherit(c.Profile); inherited.Name == profileName {
+			c.Profiles[i].Queries = append(slices.Clone(inherited.Queries), query)
+			return
+		}
+	}
+
+	c.Queries = append(c.Queries, query)
+}
+
+// inheritsQueries reports whether profile takes the queries of root, which
+// happens when both are about the same project, as in Profile.inherit.
+func inheritsQueries(profile *yaml.Node, root *yaml.Node) bool {
+	project := scalarValue(root, "project", "")
+
+	return scalarValue(profile, "project", project) == project
+}
+
+func scalarValue(node *yaml.Node, key string, fallback string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+
+	return fallback
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []Line
+	}{
+		{
+			name:   "both empty",
+			before: "",
+			after:  "",
+			want:   nil,
+		},
+		{
+			name:   "empty before",
+			before: "",
+			after:  "a\nb\n",
+			want:   []Line{{Insert, "a"}, {Insert, "b"}},
+		},
+		{
+			name:   "empty after",
+			before: "a\nb",
+			after:  "",
+			want:   []Line{{Delete, "a"}, {Delete, "b"}},
+		},
+		{
+			name:   "identical",
+			before: "a\nb\nc",
+			after:  "a\nb\nc\n",
+			want:   []Line{{Equal, "a"}, {Equal, "b"}, {Equal, "c"}},
+		},
+		{
+			name:   "insert only",
+			before: "a\nc",
+			after:  "x\na\nb\nc\ny",
+			want:   []Line{{Insert, "x"}, {Equal, "a"}, {Insert, "b"}, {Equal, "c"}, {Insert, "y"}},
+		},
+		{
+			name:   "delete only",
+			before: "x\na\nb\nc\ny",
+			after:  "a\nc",
+			want:   []Line{{Delete, "x"}, {Equal, "a"}, {Delete, "b"}, {Equal, "c"}, {Delete, "y"}},
+		},
+		{
+			name:   "replaced line",
+			before: "a\nb\nc",
+			after:  "a\nB\nc",
+			want:   []Line{{Equal, "a"}, {Delete, "b"}, {Insert, "B"}, {Equal, "c"}},
+		},
+		{
+			name:   "interleaved",
+			before: "a\nb\nc\nd\ne",
+			after:  "b\nx\nd\ne\nf",
+			want:   []Line{{Delete, "a"}, {Equal, "b"}, {Delete, "c"}, {Insert, "x"}, {Equal, "d"}, {Equal, "e"}, {Insert, "f"}},
+		},
+		{
+			name:   "blank lines",
+			before: "a\n\nb",
+			after:  "a\nb",
+			want:   []Line{{Equal, "a"}, {Delete, ""}, {Equal, "b"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Lines(test.before, test.after); !slices.Equal(got, test.want) {
+				t.Errorf("Lines(%q, %q)\n got %v\nwant %v", test.before, test.after, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package azhttpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// AzError is the decoded body of a failed Azure DevOps request.
+type AzError struct {
+	StatusCode int    `json:"-"`
+	ID         string `json:"$id"`
+	TypeName   string `json:"typeName"`
+	TypeKey    string `json:"typeKey"`
+	ErrorCode  int    `json:"errorCode"`
+	EventID    int    `json:"eventId"`
+	Message    string `json:"message"`
+}
+
+func (e *AzError) Error() string {
+	if e.TypeKey == "" {
+		return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("request failed with status %d (%s): %s", e.StatusCode, e.TypeKey, e.Message)
+}
+
+// checkResponse turns a failed response into an *AzError. Azure DevOps answers
+// requests with a rejected PAT with a 203 and its sign-in page, so that status
+// is treated as a failure too.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 && resp.StatusCode != http.StatusNonAuthoritativeInfo {
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	azErr := &AzError{StatusCode: resp.StatusCode}
+
+	if err := json.Unmarshal(body, azErr); err != nil || azErr.Message == "" {
+		azErr.Message = strings.TrimSpace(string(body))
+	}
+
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		azErr.Message = "the personal access token was rejected"
+	}
+
+	return azErr
+}
+
+// AsAzError returns the *AzError wrapped in err, if any.
+func AsAzError(err error) (*AzError, bool) {
+	var azErr *AzError
+	ok := errors.As(err, &azErr)
+	return azErr, ok
+}
+
+// IsUnauthorized reports whether err was caused by a missing, expired or
+// under-scoped personal access token.
+func IsUnauthorized(err error) bool {
+	azErr, ok := AsAzError(err)
+	return ok && (azErr.StatusCode == http.StatusUnauthorized || azErr.StatusCode == http.StatusNonAuthoritativeInfo || azErr.StatusCode == http.StatusForbidden)
+}
+
+func IsNotFound(err error) bool {
+	azErr, ok := AsAzError(err)
+	return ok && azErr.StatusCode == http.StatusNotFound
+}
+
+func IsThrottled(err error) bool {
+	azErr, ok := AsAzError(err)
+	return ok && (azErr.StatusCode == http.StatusTooManyRequests || azErr.StatusCode == http.StatusServiceUnavailable)
+}
+
+// IsConflict reports whether an update was rejected because the resource
+// changed since it was read, such as a failed JSON Patch test of /rev.
+func IsConflict(err error) bool {
+	azErr, ok := AsAzError(err)
+	return ok && (azErr.StatusCode == http.StatusPreconditionFailed || azErr.StatusCode == http.StatusConflict)
+}
+
+// IsQueryError reports whether err is a WIQL syntax or validation error.
+func IsQueryError(err error) bool {
+	azErr, ok := AsAzError(err)
+	return ok && azErr.StatusCode == http.StatusBadRequest && strings.Contains(azErr.TypeKey, "Query")
+}
@@ -1,99 +1,174 @@
 package azhttpclient
 
 import (
-	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"lazyaz/internal/config"
+	"maps"
 	"net/http"
-	"os"
+	"net/url"
 )
 
 type AzHttpClient struct {
-	client *http.Client
-	pat    string
+	client       *http.Client
+	pat          string
+	patErr       error
+	organization string
+	project      string
+	team         string
+	apiVersion   string
+	retry        RetryPolicy
+	onRetry      func(RetryEvent)
 }
 
-func NewAzHttpClient() *AzHttpClient {
+// NewAzHttpClient creates a client bound to the organization, project, PAT and
+// request timeout of profile. An unresolvable PAT leaves the client without
+// one, which ValidatePat reports.
+func NewAzHttpClient(profile config.Profile) *AzHttpClient {
+	pat, err := profile.Pat()
+	return NewAzHttpClientWithPat(profile, pat, err)
+}
+
+// NewAzHttpClientWithPat creates the client of profile with a PAT resolved
+// beforehand, patErr being the error resolving it.
+func NewAzHttpClientWithPat(profile config.Profile, pat string, patErr error) *AzHttpClient {
+	if patErr == nil && pat == "" {
+		patErr = fmt.Errorf("no personal access token found, set %s", profile.PatSource())
+	}
+
 	return &AzHttpClient{
-		client: &http.Client{},
-		pat:    os.Getenv("AZURE_DEVOPS_PAT"),
+		client:       &http.Client{Timeout: profile.Timeout},
+		pat:          pat,
+		patErr:       patErr,
+		organization: profile.Organization,
+		project:      profile.Project,
+		team:         profile.TeamName(),
+		apiVersion:   profile.ApiVersion,
+		retry: RetryPolicy{
+			MaxAttempts: max(1, profile.Retry.MaxAttempts),
+			BaseDelay:   profile.Retry.BaseDelay,
+			MaxDelay:    profile.Retry.MaxDelay,
+		},
 	}
 }
 
-func (c *AzHttpClient) HasValidPat() bool {
-	return c.pat != ""
+// SetRetryNotifier registers a callback invoked before every retry, used to
+// tell the user the client is being throttled.
+func (c *AzHttpClient) SetRetryNotifier(notify func(RetryEvent)) {
+	c.onRetry = notify
 }
 
-func (c *AzHttpClient) SetHeaders(req *http.Request) {
-	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+c.pat))
+// OrganizationUrl builds an organization scoped API url for path, adding the
+// configured api-version unless query already sets one.
+func (c *AzHttpClient) OrganizationUrl(path string, query url.Values) string {
+	return c.buildUrl(c.organization+"/"+path, query)
+}
 
-	req.Header.Set("Authorization", authHeader)
-	req.Header.Set("Content-Type", "application/json")
+// ProjectUrl builds a project scoped API url for path, adding the configured
+// api-version unless query already sets one.
+func (c *AzHttpClient) ProjectUrl(path string, query url.Values) string {
+	return c.buildUrl(c.organization+"/"+url.PathEscape(c.project)+"/"+path, query)
 }
 
-func Post[TRequest any, TResponse any](c *AzHttpClient, url string, body TRequest) (TResponse, error) {
-	var result TResponse
+// TeamUrl builds a team scoped API url for path, such as the team settings and
+// boards, adding the configured api-version unless query already sets one.
+func (c *AzHttpClient) TeamUrl(path string, query url.Values) string {
+	return c.buildUrl(c.organization+"/"+url.PathEscape(c.project)+"/"+url.PathEscape(c.team)+"/"+path, query)
+}
 
-	payload, err := json.Marshal(body)
-	if err != nil {
-		return result, fmt.Errorf("failed to parse the body: %w", err)
+// ResourceUrl returns the organization scoped url identifying a resource, as
+// used in work item relations, without api-version.
+func (c *AzHttpClient) ResourceUrl(path string) string {
+	return c.organization + "/" + path
+}
+
+func (c *AzHttpClient) buildUrl(base string, query url.Values) string {
+	query = maps.Clone(query)
+	if query == nil {
+		query = url.Values{}
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
-	if err != nil {
-		log.Fatalf("Failed to create request: %v", err)
+	if !query.Has("api-version") {
+		query.Set("api-version", c.apiVersion)
 	}
 
-	c.SetHeaders(req)
+	return base + "?" + query.Encode()
+}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return result, fmt.Errorf("failed to perform request: %w", err)
-	}
+// PreviewApiVersion returns the configured api-version for a resource still
+// in preview, such as work item comments, e.g. "7.1-preview.3".
+func (c *AzHttpClient) PreviewApiVersion(resourceVersion int) string {
+	return fmt.Sprintf("%s-preview.%d", c.apiVersion, resourceVersion)
+}
 
-	defer resp.Body.Close()
+// ValidatePat reports why the client has no usable personal access token.
+func (c *AzHttpClient) ValidatePat() error {
+	return c.patErr
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		body, _ := io.ReadAll(resp.Body)
-		return result, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
-	}
+func (c *AzHttpClient) SetHeaders(req *http.Request) {
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+c.pat))
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return result, fmt.Errorf("failed to read response body: %w", err)
-	}
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("Content-Type", ContentTypeJson)
+}
+
+func Get[T any](ctx context.Context, c *AzHttpClient, url string, opts ...RequestOption) (T, error) {
+	return do[T](ctx, c, http.MethodGet, url, nil, opts)
+}
+
+func Post[TRequest any, TResponse any](ctx context.Context, c *AzHttpClient, url string, body TRequest, opts ...RequestOption) (TResponse, error) {
+	return doWithBody[TRequest, TResponse](ctx, c, http.MethodPost, url, body, opts)
+}
+
+// Patch sends body as a JSON Patch document unless another content type is
+// given with WithContentType.
+func Patch[TRequest any, TResponse any](ctx context.Context, c *AzHttpClient, url string, body TRequest, opts ...RequestOption) (TResponse, error) {
+	opts = append([]RequestOption{WithContentType(ContentTypeJsonPatch)}, opts...)
+	return doWithBody[TRequest, TResponse](ctx, c, http.MethodPatch, url, body, opts)
+}
+
+func Put[TRequest any, TResponse any](ctx context.Context, c *AzHttpClient, url string, body TRequest, opts ...RequestOption) (TResponse, error) {
+	opts = append([]RequestOption{Idempotent()}, opts...)
+	return doWithBody[TRequest, TResponse](ctx, c, http.MethodPut, url, body, opts)
+}
 
-	err = json.Unmarshal(respBody, &result)
+func Delete[T any](ctx context.Context, c *AzHttpClient, url string, opts ...RequestOption) (T, error) {
+	opts = append([]RequestOption{Idempotent()}, opts...)
+	return do[T](ctx, c, http.MethodDelete, url, nil, opts)
+}
+
+func doWithBody[TRequest any, TResponse any](ctx context.Context, c *AzHttpClient, method, url string, body TRequest, opts []RequestOption) (TResponse, error) {
+	var result TResponse
+
+	payload, err := json.Marshal(body)
 	if err != nil {
-		return result, fmt.Errorf("failed to unmarshal response: %w", err)
+		return result, fmt.Errorf("failed to parse the body: %w", err)
 	}
 
-	return result, nil
+	return do[TResponse](ctx, c, method, url, payload, opts)
 }
 
-func Get[T any](c *AzHttpClient, url string) (T, error) {
+func do[T any](ctx context.Context, c *AzHttpClient, method, url string, payload []byte, opts []RequestOption) (T, error) {
 	var result T
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return result, fmt.Errorf("failed to create request: %w", err)
+	var options requestOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	c.SetHeaders(req)
-
-	resp, err := c.client.Do(req)
+	resp, err := c.send(ctx, method, url, payload, options)
 	if err != nil {
 		return result, fmt.Errorf("failed to perform request: %w", err)
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		body, _ := io.ReadAll(resp.Body)
-		return result, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+	if err := checkResponse(resp); err != nil {
+		return result, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -101,6 +176,10 @@ func Get[T any](c *AzHttpClient, url string) (T, error) {
 		return result, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if len(body) == 0 {
+		return result, nil
+	}
+
 	err = json.Unmarshal(body, &result)
 	if err != nil {
 		return result, fmt.Errorf("failed to unmarshal response: %w", err)
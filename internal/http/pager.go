@@ -0,0 +1,154 @@
+package azhttpclient
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListResponse is the envelope Azure DevOps wraps collections in.
+type ListResponse[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+// PageFunc loads the page identified by token, "" being the first page, and
+// returns its items with the token of the following page, "" after the last one.
+type PageFunc[T any] func(ctx context.Context, token string) ([]T, string, error)
+
+// Pager walks the pages of a PageFunc one at a time. It is not safe for
+// concurrent use: callers must wait for Next to return before calling it again.
+type Pager[T any] struct {
+	fetch PageFunc[T]
+	token string
+	done  bool
+}
+
+func NewPager[T any](fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{fetch: fetch}
+}
+
+func (p *Pager[T]) HasMore() bool {
+	return p != nil && !p.done
+}
+
+// Next loads the following page. Once the last page has been returned HasMore
+// reports false and Next returns no items.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	items, token, err := p.fetch(ctx, p.token)
+	if err != nil {
+		return nil, err
+	}
+
+	p.token = token
+	p.done = token == ""
+
+	return items, nil
+}
+
+// Pages lazily yields the remaining pages, stopping after the first error.
+func (p *Pager[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		for p.HasMore() {
+			items, err := p.Next(ctx)
+			if !yield(items, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// SkipTopPages pages through a list endpoint with $top and $skip, assuming a
+// short page is the last one.
+func SkipTopPages[T any](c *AzHttpClient, pageUrl string, top int) PageFunc[T] {
+	return func(ctx context.Context, token string) ([]T, string, error) {
+		skip, _ := strconv.Atoi(token)
+
+		requestUrl := withQuery(pageUrl, url.Values{"$top": {strconv.Itoa(top)}, "$skip": {strconv.Itoa(skip)}})
+
+		response, err := do[ListResponse[T]](ctx, c, http.MethodGet, requestUrl, nil, nil)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(response.Value) < top {
+			return response.Value, "", nil
+		}
+
+		return response.Value, strconv.Itoa(skip + top), nil
+	}
+}
+
+// SlicePages splits a list of keys known upfront, such as the ids returned by
+// a WIQL query, into pages of size and loads each page with load.
+func SlicePages[K any, T any](keys []K, size int, load func(ctx context.Context, keys []K) ([]T, error)) PageFunc[T] {
+	return func(ctx context.Context, token string) ([]T, string, error) {
+		start, _ := strconv.Atoi(token)
+		end := min(start+size, len(keys))
+
+		items, err := load(ctx, keys[start:end])
+		if err != nil {
+			return nil, "", err
+		}
+
+		if end == len(keys) {
+			return items, "", nil
+		}
+
+		return items, strconv.Itoa(end), nil
+	}
+}
+
+// ConcatPages walks every source one after the other, as when listing the
+// pull requests of several repositories.
+func ConcatPages[T any](sources ...PageFunc[T]) PageFunc[T] {
+	return func(ctx context.Context, token string) ([]T, string, error) {
+		index, inner := 0, ""
+		if token != "" {
+			position, rest, _ := strings.Cut(token, ":")
+			index, _ = strconv.Atoi(position)
+			inner = rest
+		}
+
+		if index >= len(sources) {
+			return nil, "", nil
+		}
+
+		items, next, err := sources[index](ctx, inner)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch {
+		case next != "":
+			return items, fmt.Sprintf("%d:%s", index, next), nil
+		case index+1 < len(sources):
+			return items, fmt.Sprintf("%d:", index+1), nil
+		default:
+			return items, "", nil
+		}
+	}
+}
+
+func withQuery(pageUrl string, query url.Values) string {
+	parsed, err := url.Parse(pageUrl)
+	if err != nil {
+		return pageUrl
+	}
+
+	values := parsed.Query()
+	for key, value := range query {
+		values[key] = value
+	}
+	parsed.RawQuery = values.Encode()
+
+	return parsed.String()
+}
@@ -0,0 +1,37 @@
+package azhttpclient
+
+const (
+	ContentTypeJson      = "application/json"
+	ContentTypeJsonPatch = "application/json-patch+json"
+)
+
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotent  bool
+	contentType string
+}
+
+// Idempotent marks a POST or PATCH request as safe to retry, such as a WIQL
+// query. GET, PUT and DELETE requests are always retried.
+func Idempotent() RequestOption {
+	return func(o *requestOptions) {
+		o.idempotent = true
+	}
+}
+
+// WithContentType overrides the application/json content type set by SetHeaders.
+func WithContentType(contentType string) RequestOption {
+	return func(o *requestOptions) {
+		o.contentType = contentType
+	}
+}
+
+// PatchOperation is a single JSON Patch (RFC 6902) operation, as used to
+// update work item fields and relations.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
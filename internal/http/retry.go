@@ -0,0 +1,128 @@
+package azhttpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how throttled or failed idempotent requests are retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// RetryEvent describes a retry that is about to happen after Wait.
+type RetryEvent struct {
+	Attempt    int
+	Wait       time.Duration
+	StatusCode int
+	Err        error
+}
+
+func (e RetryEvent) Throttled() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusServiceUnavailable
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// send performs the request, retrying GETs and idempotent requests that failed
+// at the network level or were throttled, as allowed by the retry policy.
+// Waiting between attempts stops as soon as ctx is done.
+func (c *AzHttpClient) send(ctx context.Context, method, url string, payload []byte, options requestOptions) (*http.Response, error) {
+	retryable := method == http.MethodGet || options.idempotent
+
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+
+		c.SetHeaders(req)
+		if options.contentType != "" {
+			req.Header.Set("Content-Type", options.contentType)
+		}
+
+		resp, err := c.client.Do(req)
+		if !retryable || attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+
+		event := RetryEvent{Attempt: attempt, Err: err}
+		switch {
+		case err != nil:
+			event.Wait = c.retry.backoff(attempt)
+		case isRetryableStatus(resp.StatusCode):
+			event.StatusCode = resp.StatusCode
+			event.Wait = retryAfter(resp)
+			if event.Wait <= 0 {
+				event.Wait = c.retry.backoff(attempt)
+			}
+
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if c.onRetry != nil {
+			c.onRetry(event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(event.Wait):
+		}
+	}
+}
+
+// backoff returns the exponential delay before the next attempt, with jitter
+// so concurrent requests do not retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryAfter reads the delay requested by Azure DevOps from the Retry-After
+// header, or from X-RateLimit-Reset when only the rate limit headers are sent.
+func retryAfter(resp *http.Response) time.Duration {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+
+		if date, err := http.ParseTime(value); err == nil {
+			return time.Until(date)
+		}
+	}
+
+	if value := resp.Header.Get("X-RateLimit-Reset"); value != "" {
+		if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return time.Until(time.Unix(epoch, 0))
+		}
+	}
+
+	return 0
+}
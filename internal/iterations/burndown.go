@@ -0,0 +1,173 @@
+package iterations
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	iterations "lazyaz/internal/iterations/models"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+// historyConcurrency bounds the updates loaded at the same time.
+const historyConcurrency = 8
+
+// BurndownMsg carries the work items of Iteration with their updates, and the
+// capacity of the team over the iteration.
+type BurndownMsg struct {
+	Iteration  iterations.Iteration
+	Items      []workitemsmodels.WorkItem
+	Updates    map[int][]workitemsmodels.WorkItemUpdate
+	Capacities []iterations.Capacity
+	Schedule   iterations.Schedule
+}
+
+// FetchBurndown loads what the burndown of iteration is drawn from, or of the
+// current iteration when it is not set.
+func FetchBurndown(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, iteration iterations.Iteration) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the burndown", Err: err}
+		}
+
+		msg, err := fetchBurndown(ctx, azHttpClient, iteration)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not fetch the burndown", Err: err}
+		}
+
+		return msg
+	}
+}
+
+func fetchBurndown(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, iteration iterations.Iteration) (BurndownMsg, error) {
+	if iteration.ID == "" {
+		current, err := FetchCurrentIteration(ctx, azHttpClient)
+		if err != nil {
+			return BurndownMsg{}, err
+		}
+		iteration = current
+	}
+
+	ids, err := FetchIterationWorkItemIDs(ctx, azHttpClient, iteration.ID)
+	if err != nil {
+		return BurndownMsg{}, err
+	}
+
+	msg := BurndownMsg{Iteration: iteration, Updates: make(map[int][]workitemsmodels.WorkItemUpdate, len(ids))}
+	updates := make([][]workitemsmodels.WorkItemUpdate, len(ids))
+	iterationUrl := "_apis/work/teamsettings/iterations/" + url.PathEscape(iteration.ID)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() (err error) {
+		msg.Items, err = workitems.FetchWorkItemsByIds(groupCtx, azHttpClient, ids)
+		return err
+	})
+
+	group.Go(func() error {
+		history, historyCtx := errgroup.WithContext(groupCtx)
+		history.SetLimit(historyConcurrency)
+
+		for index, id := range ids {
+			history.Go(func() (err error) {
+				updates[index], err = workitems.FetchUpdates(historyCtx, azHttpClient, id)
+				return err
+			})
+		}
+
+		return history.Wait()
+	})
+
+	group.Go(func() error {
+		capacities, err := azhttpclient.Get[teamCapacity](groupCtx, azHttpClient, azHttpClient.TeamUrl(iterationUrl+"/capacities", nil))
+		msg.Capacities = append(capacities.TeamMembers, capacities.Value...)
+		return err
+	})
+
+	group.Go(func() error {
+		daysOff, err := azhttpclient.Get[iterations.Schedule](groupCtx, azHttpClient, azHttpClient.TeamUrl(iterationUrl+"/teamdaysoff", nil))
+		msg.Schedule.DaysOff = daysOff.DaysOff
+		return err
+	})
+
+	group.Go(func() error {
+		settings, err := azhttpclient.Get[iterations.Schedule](groupCtx, azHttpClient, azHttpClient.TeamUrl("_apis/work/teamsettings", nil))
+		msg.Schedule.WorkingDays = settings.WorkingDays
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		return BurndownMsg{}, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return BurndownMsg{}, err
+	}
+
+	for index, id := range ids {
+		msg.Updates[id] = updates[index]
+	}
+
+	return msg, nil
+}
+
+// teamCapacity is the capacity of the team members, listed in TeamMembers
+// since api-version 7.1 and in Value before.
+type teamCapacity struct {
+	TeamMembers []iterations.Capacity `json:"teamMembers"`
+	Value       []iterations.Capacity `json:"value"`
+}
+
+// Remaining returns the remaining work planned in the iteration at the end of
+// each of days, replaying the updates of its work items. Work items count
+// only on the days they were in the iteration.
+func (b BurndownMsg) Remaining(days []time.Time) []float64 {
+	values := make([]float64, len(days))
+
+	for _, updates := range b.Updates {
+		var remaining float64
+		var iterationPath string
+
+		next := 0
+		for index, day := range days {
+			end := day.AddDate(0, 0, 1)
+			for ; next < len(updates) && updates[next].ChangedAt().Before(end); next++ {
+				if value, ok := updates[next].NumberChange(workitemsmodels.RemainingWorkField); ok {
+					remaining = value
+				}
+
+				if change, ok := updates[next].Fields["System.IterationPath"]; ok {
+					iterationPath = fmt.Sprint(change.NewValue)
+				}
+			}
+
+			if isUnder(iterationPath, b.Iteration.Path) {
+				values[index] += remaining
+			}
+		}
+	}
+
+	return values
+}
+
+// AssignedWork returns the remaining work of the iteration by the unique name
+// of the assignee, in lower case, unassigned work under "".
+func (b BurndownMsg) AssignedWork() map[string]float64 {
+	assigned := make(map[string]float64)
+	for _, item := range b.Items {
+		assigned[strings.ToLower(item.Fields.AssignedTo.UniqueName)] += item.Fields.RemainingWork
+	}
+
+	return assigned
+}
+
+func isUnder(path string, parent string) bool {
+	return strings.EqualFold(path, parent) || strings.HasPrefix(strings.ToLower(path), strings.ToLower(parent)+`\`)
+}
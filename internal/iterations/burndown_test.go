@@ -0,0 +1,108 @@
+package iterations
+
+import (
+	iterations "lazyaz/internal/iterations/models"
+	workitems "lazyaz/internal/work-items/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBurndownRemaining(t *testing.T) {
+	sprint := iterations.Iteration{Path: `Fabrikam\Sprint 1`}
+
+	var days []time.Time
+	for day := 4; day <= 8; day++ {
+		days = append(days, time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC))
+	}
+
+	update := func(changedAt string, fields map[string]any) workitems.WorkItemUpdate {
+		changes := map[string]workitems.FieldChange{"System.ChangedDate": {NewValue: changedAt}}
+		for name, value := range fields {
+			changes[name] = workitems.FieldChange{NewValue: value}
+		}
+
+		return workitems.WorkItemUpdate{Fields: changes}
+	}
+
+	tests := []struct {
+		name    string
+		updates [][]workitems.WorkItemUpdate
+		want    []float64
+	}{
+		{
+			name: "burnt down",
+			updates: [][]workitems.WorkItemUpdate{{
+				update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`, workitems.RemainingWorkField: 8.0}),
+				update("2024-03-05T16:30:00Z", map[string]any{workitems.RemainingWorkField: 5.0}),
+			}},
+			want: []float64{8, 5, 5, 5, 5},
+		},
+		{
+			name: "remaining work cleared",
+			updates: [][]workitems.WorkItemUpdate{{
+				update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`, workitems.RemainingWorkField: 8.0}),
+				update("2024-03-06T10:00:00Z", map[string]any{workitems.RemainingWorkField: nil}),
+			}},
+			want: []float64{8, 8, 0, 0, 0},
+		},
+		{
+			name: "added to the sprint mid-way",
+			updates: [][]workitems.WorkItemUpdate{{
+				update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam`, workitems.RemainingWorkField: 4.0}),
+				update("2024-03-06T23:59:59Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`}),
+			}},
+			want: []float64{0, 0, 4, 4, 4},
+		},
+		{
+			name: "moved out of the sprint",
+			updates: [][]workitems.WorkItemUpdate{{
+				update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`, workitems.RemainingWorkField: 3.0}),
+				update("2024-03-07T00:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 2`}),
+			}},
+			want: []float64{3, 3, 3, 0, 0},
+		},
+		{
+			name: "created during the sprint",
+			updates: [][]workitems.WorkItemUpdate{{
+				update("2024-03-05T08:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`, workitems.RemainingWorkField: 2.0}),
+			}},
+			want: []float64{0, 2, 2, 2, 2},
+		},
+		{
+			name: "iteration paths",
+			updates: [][]workitems.WorkItemUpdate{
+				{update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `fabrikam\sprint 1\Team A`, workitems.RemainingWorkField: 1.0})},
+				{update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 10`, workitems.RemainingWorkField: 10.0})},
+			},
+			want: []float64{1, 1, 1, 1, 1},
+		},
+		{
+			name: "several items",
+			updates: [][]workitems.WorkItemUpdate{
+				{
+					update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`, workitems.RemainingWorkField: 8.0}),
+					update("2024-03-05T16:30:00Z", map[string]any{workitems.RemainingWorkField: 5.0}),
+				},
+				{
+					update("2024-03-01T09:00:00Z", map[string]any{"System.IterationPath": `Fabrikam`, workitems.RemainingWorkField: 4.0}),
+					update("2024-03-06T12:00:00Z", map[string]any{"System.IterationPath": `Fabrikam\Sprint 1`}),
+				},
+			},
+			want: []float64{8, 5, 9, 9, 9},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := BurndownMsg{Iteration: sprint, Updates: make(map[int][]workitems.WorkItemUpdate)}
+			for id, updates := range test.updates {
+				msg.Updates[id+1] = updates
+			}
+
+			if got := msg.Remaining(days); !slices.Equal(got, test.want) {
+				t.Errorf("Remaining() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
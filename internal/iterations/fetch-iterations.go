@@ -0,0 +1,82 @@
+package iterations
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	iterations "lazyaz/internal/iterations/models"
+	"lazyaz/internal/models"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IterationsMsg carries the iterations the team is subscribed to, in
+// schedule order.
+type IterationsMsg struct {
+	Iterations []iterations.Iteration
+}
+
+// FetchIterations loads the iterations of the team settings.
+func FetchIterations(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the iterations", Err: err}
+		}
+
+		iterationsUrl := azHttpClient.TeamUrl("_apis/work/teamsettings/iterations", nil)
+
+		response, err := azhttpclient.Get[azhttpclient.ListResponse[iterations.Iteration]](ctx, azHttpClient, iterationsUrl)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not fetch the iterations", Err: err}
+		}
+
+		return IterationsMsg{Iterations: response.Value}
+	}
+}
+
+// FetchCurrentIteration loads the current sprint of the team.
+func FetchCurrentIteration(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient) (iterations.Iteration, error) {
+	iterationsUrl := azHttpClient.TeamUrl("_apis/work/teamsettings/iterations", url.Values{"$timeframe": {"current"}})
+
+	response, err := azhttpclient.Get[azhttpclient.ListResponse[iterations.Iteration]](ctx, azHttpClient, iterationsUrl)
+	if err != nil {
+		return iterations.Iteration{}, err
+	}
+
+	if len(response.Value) == 0 {
+		return iterations.Iteration{}, fmt.Errorf("the team has no current iteration")
+	}
+
+	return response.Value[0], nil
+}
+
+type iterationWorkItems struct {
+	WorkItemRelations []struct {
+		Rel    *string `json:"rel"`
+		Source *struct {
+			ID int `json:"id"`
+		} `json:"source"`
+		Target struct {
+			ID int `json:"id"`
+		} `json:"target"`
+	} `json:"workItemRelations"`
+}
+
+// FetchIterationWorkItemIDs returns the ids of the work items planned in the
+// iteration, parents before their children.
+func FetchIterationWorkItemIDs(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, iterationID string) ([]int, error) {
+	workItemsUrl := azHttpClient.TeamUrl("_apis/work/teamsettings/iterations/"+url.PathEscape(iterationID)+"/workitems", nil)
+
+	response, err := azhttpclient.Get[iterationWorkItems](ctx, azHttpClient, workItemsUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, relation := range response.WorkItemRelations {
+		ids = append(ids, relation.Target.ID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,100 @@
+package iterations
+
+import (
+	"strings"
+	"time"
+)
+
+// DateRange is a span of days off, both ends included.
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (r DateRange) Contains(day time.Time) bool {
+	return !day.Before(r.Start) && !day.After(r.End)
+}
+
+type TeamMember struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+type Activity struct {
+	Name           string  `json:"name"`
+	CapacityPerDay float64 `json:"capacityPerDay"`
+}
+
+// Capacity is the time a team member can spend on the iteration each working
+// day, split by activity, and the days they are off.
+type Capacity struct {
+	TeamMember TeamMember  `json:"teamMember"`
+	Activities []Activity  `json:"activities"`
+	DaysOff    []DateRange `json:"daysOff"`
+}
+
+func (c Capacity) PerDay() float64 {
+	var total float64
+	for _, activity := range c.Activities {
+		total += activity.CapacityPerDay
+	}
+
+	return total
+}
+
+// Hours returns the capacity of the member over days, leaving out their days off.
+func (c Capacity) Hours(days []time.Time) float64 {
+	var hours float64
+	for _, day := range days {
+		off := false
+		for _, daysOff := range c.DaysOff {
+			off = off || daysOff.Contains(day)
+		}
+
+		if !off {
+			hours += c.PerDay()
+		}
+	}
+
+	return hours
+}
+
+// Schedule is when the team works: the working days of the week from the
+// team settings and the days off of the whole team in an iteration.
+type Schedule struct {
+	WorkingDays []string    `json:"workingDays"`
+	DaysOff     []DateRange `json:"daysOff"`
+}
+
+func (s Schedule) IsWorkingDay(day time.Time) bool {
+	working := len(s.WorkingDays) == 0 && day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	for _, weekday := range s.WorkingDays {
+		working = working || strings.EqualFold(weekday, day.Weekday().String())
+	}
+
+	for _, daysOff := range s.DaysOff {
+		if daysOff.Contains(day) {
+			return false
+		}
+	}
+
+	return working
+}
+
+// Days returns the working days of iteration, from its start to its finish date.
+func (s Schedule) Days(iteration Iteration) []time.Time {
+	start, finish := iteration.Attributes.StartDate, iteration.Attributes.FinishDate
+	if start == nil || finish == nil {
+		return nil
+	}
+
+	var days []time.Time
+	for day := start.UTC(); !day.After(finish.UTC()); day = day.AddDate(0, 0, 1) {
+		if s.IsWorkingDay(day) {
+			days = append(days, day)
+		}
+	}
+
+	return days
+}
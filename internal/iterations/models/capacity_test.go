@@ -0,0 +1,102 @@
+package iterations
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func march(day int) time.Time {
+	return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestScheduleDays(t *testing.T) {
+	start, finish := march(1), march(8)
+	sprint := Iteration{Attributes: Attributes{StartDate: &start, FinishDate: &finish}}
+
+	tests := []struct {
+		name      string
+		schedule  Schedule
+		iteration Iteration
+		want      []time.Time
+	}{
+		{
+			name:      "monday to friday when no working days are set",
+			schedule:  Schedule{},
+			iteration: sprint,
+			want:      []time.Time{march(1), march(4), march(5), march(6), march(7), march(8)},
+		},
+		{
+			name:      "working days of the team",
+			schedule:  Schedule{WorkingDays: []string{"sunday", "monday", "tuesday", "wednesday", "thursday"}},
+			iteration: sprint,
+			want:      []time.Time{march(3), march(4), march(5), march(6), march(7)},
+		},
+		{
+			name:      "days off of the team",
+			schedule:  Schedule{DaysOff: []DateRange{{Start: march(5), End: march(6)}}},
+			iteration: sprint,
+			want:      []time.Time{march(1), march(4), march(7), march(8)},
+		},
+		{
+			name:      "day off on a weekend",
+			schedule:  Schedule{WorkingDays: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}, DaysOff: []DateRange{{Start: march(2), End: march(3)}}},
+			iteration: sprint,
+			want:      []time.Time{march(1), march(4), march(5), march(6), march(7), march(8)},
+		},
+		{
+			name:      "iteration without dates",
+			schedule:  Schedule{},
+			iteration: Iteration{},
+			want:      nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.schedule.Days(test.iteration); !slices.EqualFunc(got, test.want, time.Time.Equal) {
+				t.Errorf("Days() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCapacityHours(t *testing.T) {
+	days := []time.Time{march(4), march(5), march(6), march(7), march(8)}
+	activities := []Activity{{Name: "Development", CapacityPerDay: 4}, {Name: "Testing", CapacityPerDay: 2}}
+
+	tests := []struct {
+		name     string
+		capacity Capacity
+		want     float64
+	}{
+		{
+			name:     "every day",
+			capacity: Capacity{Activities: activities},
+			want:     30,
+		},
+		{
+			name:     "a day off inside the range",
+			capacity: Capacity{Activities: activities, DaysOff: []DateRange{{Start: march(6), End: march(6)}}},
+			want:     24,
+		},
+		{
+			name:     "days off across the end of the range",
+			capacity: Capacity{Activities: activities, DaysOff: []DateRange{{Start: march(8), End: march(12)}, {Start: march(1), End: march(4)}}},
+			want:     18,
+		},
+		{
+			name:     "no activities",
+			capacity: Capacity{},
+			want:     0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.capacity.Hours(days); got != test.want {
+				t.Errorf("Hours() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
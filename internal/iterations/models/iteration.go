@@ -0,0 +1,33 @@
+package iterations
+
+import "time"
+
+// Iteration is a sprint of the team, with its dates when they are set.
+type Iteration struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Path       string     `json:"path"`
+	Attributes Attributes `json:"attributes"`
+	URL        string     `json:"url"`
+}
+
+type Attributes struct {
+	StartDate  *time.Time `json:"startDate"`
+	FinishDate *time.Time `json:"finishDate"`
+	TimeFrame  string     `json:"timeFrame"`
+}
+
+// IsCurrent tells whether the iteration is the current sprint of the team.
+func (i Iteration) IsCurrent() bool {
+	return i.Attributes.TimeFrame == "current"
+}
+
+// Dates returns the span of the iteration, or an empty string when its dates
+// are not set.
+func (i Iteration) Dates() string {
+	if i.Attributes.StartDate == nil || i.Attributes.FinishDate == nil {
+		return ""
+	}
+
+	return i.Attributes.StartDate.Format("Jan 2") + " – " + i.Attributes.FinishDate.Format("Jan 2, 2006")
+}
@@ -0,0 +1,18 @@
+package models
+
+import "fmt"
+
+// ErrorMsg is returned by commands that failed so the UI can report the error
+// and offer a retry instead of exiting.
+type ErrorMsg struct {
+	Action string
+	Err    error
+}
+
+func (e ErrorMsg) Error() string {
+	return fmt.Sprintf("%s: %v", e.Action, e.Err)
+}
+
+func (e ErrorMsg) Unwrap() error {
+	return e.Err
+}
@@ -1,33 +1,68 @@
 package pullrequests
 
 import (
+	"context"
 	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
 	pullrequests "lazyaz/internal/pull-requests/models"
-	"log"
+	"net/url"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-type PullRequestResponseMsg []pullrequests.PullRequest
+const pageSize = 100
 
-func FetchPullRequests() tea.Msg {
-	azHttpClient := azhttpclient.NewAzHttpClient()
+// PullRequestResponseMsg carries a page of pull requests. Pager loads the
+// following pages and Append tells whether the items follow the ones already listed.
+type PullRequestResponseMsg struct {
+	Items  []pullrequests.PullRequest
+	Pager  *azhttpclient.Pager[pullrequests.PullRequest]
+	Append bool
+}
 
-	if !azHttpClient.HasValidPat() {
-		log.Fatalf("Please set the AZURE_DEVOPS_PAT environment variable")
-	}
+// FetchPullRequests loads the first page of active pull requests of every
+// repository, or of the whole project when no repositories are given.
+func FetchPullRequests(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, repositories []string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch pull requests", Err: err}
+		}
+
+		query := url.Values{"searchCriteria.includeLinks": {"False"}}
+
+		urls := []string{azHttpClient.ProjectUrl("_apis/git/pullrequests", query)}
+		if len(repositories) > 0 {
+			urls = urls[:0]
+			for _, repository := range repositories {
+				urls = append(urls, azHttpClient.ProjectUrl("_apis/git/repositories/"+url.PathEscape(repository)+"/pullRequests", query))
+			}
+		}
+
+		var sources []azhttpclient.PageFunc[pullrequests.PullRequest]
+		for _, pullRequestsUrl := range urls {
+			sources = append(sources, azhttpclient.SkipTopPages[pullrequests.PullRequest](azHttpClient, pullRequestsUrl, pageSize))
+		}
 
-	url := "https://wkeuds.visualstudio.com/NewPOL/_apis/git/repositories/ERPRepo.Client.OINV/pullRequests?searchCriteria.includeLinks=False"
+		return fetchPage(ctx, azhttpclient.NewPager(azhttpclient.ConcatPages(sources...)), false)
+	}
+}
 
-	type Response struct {
-		Count int                    `json:"count"`
-		Value PullRequestResponseMsg `json:"value"`
+// FetchMorePullRequests loads the next page of pager, appended to the list.
+func FetchMorePullRequests(ctx context.Context, pager *azhttpclient.Pager[pullrequests.PullRequest]) tea.Cmd {
+	return func() tea.Msg {
+		return fetchPage(ctx, pager, true)
 	}
+}
 
-	items, err := azhttpclient.Get[Response](azHttpClient, url)
+func fetchPage(ctx context.Context, pager *azhttpclient.Pager[pullrequests.PullRequest], appendItems bool) tea.Msg {
+	pullRequests, err := pager.Next(ctx)
 	if err != nil {
-		log.Fatalf("could not fetch work items: %v", err)
+		return models.ErrorMsg{Action: "could not fetch pull requests", Err: err}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return models.ErrorMsg{Action: "could not fetch pull requests", Err: err}
 	}
 
-	return PullRequestResponseMsg(items.Value)
+	return PullRequestResponseMsg{Items: pullRequests, Pager: pager, Append: appendItems}
 }
@@ -2,12 +2,11 @@ package pullrequests
 
 import (
 	"fmt"
-	"log"
+	"net/url"
 	"strings"
 	"time"
 
 	md "github.com/JohannesKaufmann/html-to-markdown"
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/glamour"
 )
 
@@ -66,12 +65,12 @@ func (i PullRequest) GetID() int {
 }
 
 func (i PullRequest) GetURL() string {
-	url := strings.Replace("", "_apis/wit/workItems", "_workitems/edit", 1)
-	if err := clipboard.WriteAll(url); err != nil {
-		log.Fatalf("Failed to copy to clipboard: %v", err)
+	if i.Repository == nil {
+		return ""
 	}
 
-	return url
+	repositoryUrl := strings.Replace(i.Repository.Url, "_apis/git/repositories/"+i.Repository.ID, "_git/"+url.PathEscape(i.Repository.Name), 1)
+	return fmt.Sprintf("%s/pullrequest/%d", repositoryUrl, i.PullRequestID)
 }
 
 func (i PullRequest) GetPreview(renderer *glamour.TermRenderer) string {
@@ -0,0 +1,93 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commentsApiVersion is the version of the comments resource, still in
+// preview, available since api-version 5.1.
+const commentsApiVersion = 3
+
+const commentsPageSize = 100
+
+// CommentsMsg carries the latest comments of a work item, newest first, or the
+// error loading them.
+type CommentsMsg struct {
+	WorkItemID int
+	Comments   []workitems.Comment
+	TotalCount int
+	Err        error
+}
+
+// FetchComments loads the latest comments of the work item id.
+func FetchComments(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, id int) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return CommentsMsg{WorkItemID: id, Err: err}
+		}
+
+		query := url.Values{
+			"$top":        {fmt.Sprint(commentsPageSize)},
+			"order":       {"desc"},
+			"api-version": {azHttpClient.PreviewApiVersion(commentsApiVersion)},
+		}
+		commentsUrl := azHttpClient.ProjectUrl(fmt.Sprintf("_apis/wit/workItems/%d/comments", id), query)
+
+		list, err := azhttpclient.Get[workitems.CommentList](ctx, azHttpClient, commentsUrl)
+		if err != nil {
+			return CommentsMsg{WorkItemID: id, Err: models.ErrorMsg{Action: fmt.Sprintf("could not fetch the comments of #%d", id), Err: err}}
+		}
+
+		return CommentsMsg{WorkItemID: id, Comments: list.Comments, TotalCount: list.TotalCount}
+	}
+}
+
+type CommentPostedMsg struct {
+	Comment workitems.Comment
+}
+
+type commentPayload struct {
+	Text string `json:"text"`
+}
+
+// PostComment adds a comment to the work item id. markdown is converted to
+// HTML and every "@Display Name" of mentions becomes an @mention.
+func PostComment(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, id int, markdown string, mentions []workitems.Identity) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not post the comment", Err: err}
+		}
+
+		text, err := workitems.MarkdownToHtml(markdown)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not convert the comment to HTML", Err: err}
+		}
+
+		for _, identity := range mentions {
+			text = strings.ReplaceAll(text, "@"+escapeHtml(identity.DisplayName), workitems.MentionHtml(identity))
+		}
+
+		query := url.Values{"api-version": {azHttpClient.PreviewApiVersion(commentsApiVersion)}}
+		commentsUrl := azHttpClient.ProjectUrl(fmt.Sprintf("_apis/wit/workItems/%d/comments", id), query)
+
+		comment, err := azhttpclient.Post[commentPayload, workitems.Comment](ctx, azHttpClient, commentsUrl, commentPayload{Text: text})
+		if err != nil {
+			return models.ErrorMsg{Action: fmt.Sprintf("could not post the comment on #%d", id), Err: err}
+		}
+
+		return CommentPostedMsg{Comment: comment}
+	}
+}
+
+// escapeHtml escapes s the way goldmark escapes text.
+func escapeHtml(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
@@ -0,0 +1,135 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+)
+
+type WorkItemTypesMsg []workitems.WorkItemType
+
+// FetchWorkItemTypes loads the work item types that can be created in the project.
+func FetchWorkItemTypes(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch work item types", Err: err}
+		}
+
+		types, err := azhttpclient.Get[azhttpclient.ListResponse[workitems.WorkItemType]](ctx, azHttpClient, azHttpClient.ProjectUrl("_apis/wit/workitemtypes", nil))
+		if err != nil {
+			return models.ErrorMsg{Action: "could not fetch work item types", Err: err}
+		}
+
+		var enabled WorkItemTypesMsg
+		for _, workItemType := range types.Value {
+			if !workItemType.IsDisabled {
+				enabled = append(enabled, workItemType)
+			}
+		}
+
+		return enabled
+	}
+}
+
+// CreationFormMsg carries what is needed to fill a new work item of Type.
+type CreationFormMsg struct {
+	Type       workitems.WorkItemType
+	Areas      []string
+	Iterations []string
+}
+
+// FetchCreationForm loads the fields of the type called typeName together with
+// the areas and iterations of the project.
+func FetchCreationForm(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, typeName string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not load the work item form", Err: err}
+		}
+
+		var msg CreationFormMsg
+
+		group, ctx := errgroup.WithContext(ctx)
+
+		group.Go(func() (err error) {
+			msg.Type, err = fetchWorkItemType(ctx, azHttpClient, typeName)
+			return err
+		})
+
+		group.Go(func() error {
+			areas, err := FetchClassificationNodes(ctx, azHttpClient, "areas")
+			msg.Areas = areas.FieldPaths()
+			return err
+		})
+
+		group.Go(func() error {
+			iterations, err := FetchClassificationNodes(ctx, azHttpClient, "iterations")
+			msg.Iterations = iterations.FieldPaths()
+			return err
+		})
+
+		if err := group.Wait(); err != nil {
+			return models.ErrorMsg{Action: "could not load the work item form", Err: err}
+		}
+
+		return msg
+	}
+}
+
+// FetchClassificationNodes loads the tree of areas or iterations of the project.
+func FetchClassificationNodes(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, group string) (workitems.ClassificationNode, error) {
+	nodesUrl := azHttpClient.ProjectUrl("_apis/wit/classificationnodes/"+group, url.Values{"$depth": {"10"}})
+	return azhttpclient.Get[workitems.ClassificationNode](ctx, azHttpClient, nodesUrl)
+}
+
+type WorkItemCreatedMsg struct {
+	Item workitems.WorkItem
+}
+
+// CreateWorkItem creates a work item of the type called typeName with the
+// given fields, as a child of parentID when it is not zero.
+func CreateWorkItem(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, typeName string, fields []FieldEdit, parentID int) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not create the work item", Err: err}
+		}
+
+		var operations []azhttpclient.PatchOperation
+		for _, field := range fields {
+			operations = append(operations, SetField(field.ReferenceName, field.Value))
+		}
+
+		if parentID != 0 {
+			operations = append(operations, AddRelation(azHttpClient, workitems.ParentRelation, parentID, ""))
+		}
+
+		createUrl := azHttpClient.ProjectUrl("_apis/wit/workitems/$"+url.PathEscape(typeName), nil)
+
+		item, err := azhttpclient.Post[[]azhttpclient.PatchOperation, workitems.WorkItem](ctx, azHttpClient, createUrl, operations, azhttpclient.WithContentType(azhttpclient.ContentTypeJsonPatch))
+		if err != nil {
+			return models.ErrorMsg{Action: fmt.Sprintf("could not create the %s", typeName), Err: err}
+		}
+
+		return WorkItemCreatedMsg{Item: item}
+	}
+}
+
+// AddRelation returns the operation linking a work item to targetID with the
+// relation type rel.
+func AddRelation(azHttpClient *azhttpclient.AzHttpClient, rel string, targetID int, comment string) azhttpclient.PatchOperation {
+	relation := map[string]any{
+		"rel": rel,
+		"url": azHttpClient.ResourceUrl(fmt.Sprintf("_apis/wit/workItems/%d", targetID)),
+	}
+
+	if comment != "" {
+		relation["attributes"] = map[string]any{"comment": comment}
+	}
+
+	return azhttpclient.PatchOperation{Op: "add", Path: "/relations/-", Value: relation}
+}
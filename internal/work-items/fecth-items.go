@@ -1,63 +1,246 @@
 package workitems
 
 import (
-	"fmt"
+	"context"
 	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
 	workitems "lazyaz/internal/work-items/models"
-	"log"
-	"strings"
+	"net/url"
+	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
 )
 
 type QueryPayload struct {
 	Query string `json:"query"`
 }
 
+type workItemReference struct {
+	ID int `json:"id"`
+}
+
+// WorkItemsResponse is the result of a WIQL query. Flat queries fill WorkItems
+// while tree and one-hop queries fill WorkItemRelations.
 type WorkItemsResponse struct {
-	WorkItems []struct {
-		ID int `json:"id"`
-	} `json:"workItems"`
+	QueryType         string              `json:"queryType"`
+	WorkItems         []workItemReference `json:"workItems"`
+	WorkItemRelations []struct {
+		Source *workItemReference `json:"source"`
+		Target *workItemReference `json:"target"`
+	} `json:"workItemRelations"`
 }
 
-type WorkItemsResponseMsg []workitems.WorkItem
+// IDs returns the ids of the work items matched by the query, in query order.
+func (r WorkItemsResponse) IDs() []int {
+	var ids []int
+	for _, item := range r.WorkItems {
+		ids = append(ids, item.ID)
+	}
 
-func FetchWorkItems() tea.Msg {
-	azHttpClient := azhttpclient.NewAzHttpClient()
+	seen := make(map[int]bool)
+	for _, relation := range r.WorkItemRelations {
+		if relation.Target != nil && !seen[relation.Target.ID] {
+			seen[relation.Target.ID] = true
+			ids = append(ids, relation.Target.ID)
+		}
+	}
 
-	if !azHttpClient.HasValidPat() {
-		log.Fatalf("Please set the AZURE_DEVOPS_PAT environment variable")
+	return ids
+}
+
+// WorkItemsResponseMsg carries a page of work items. Pager loads the following
+// pages and Append tells whether the items follow the ones already listed.
+type WorkItemsResponseMsg struct {
+	Items  []workitems.WorkItem
+	Pager  *azhttpclient.Pager[workitems.WorkItem]
+	Append bool
+}
+
+// FetchWorkItems runs wiql and loads the first page of matching work items. Once
+// ctx is cancelled the command reports context.Canceled instead of stale results.
+func FetchWorkItems(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, wiql string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch work items", Err: err}
+		}
+
+		return runWiql(ctx, azHttpClient, wiql)
 	}
+}
 
-	url := "https://wkeuds.visualstudio.com/_apis/wit/wiql?api-version=5"
+func runWiql(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, wiql string) tea.Msg {
+	wiqlUrl := azHttpClient.ProjectUrl("_apis/wit/wiql", nil)
 
 	payload := QueryPayload{
-		Query: `SELECT * FROM WorkItems WHERE [System.ChangedDate] >= @Today - 60 AND [System.AssignedTo] = @Me AND [System.NodeName] IN ('Krypton Team', 'Atalaya Team', 'Eternia Team', 'Castillo Grayskull', 'Estación Zeta') AND [System.WorkItemType] IN ('Task', 'User Story', 'Bug', 'Defect')`,
+		Query: wiql,
 	}
 
-	data, error := azhttpclient.Post[QueryPayload, WorkItemsResponse](azHttpClient, url, payload)
-	if error != nil {
-		log.Fatalf("could not fetch work items: %v", error)
+	data, err := azhttpclient.Post[QueryPayload, WorkItemsResponse](ctx, azHttpClient, wiqlUrl, payload, azhttpclient.Idempotent())
+	if err != nil {
+		return models.ErrorMsg{Action: "could not run the work items query", Err: err}
 	}
 
-	totalWorkItemsUrl := "https://wkeuds.visualstudio.com/_apis/wit/workItems?ids="
+	return fetchFirstPage(ctx, azHttpClient, data.IDs())
+}
+
+// FetchWorkItemsByQuery runs the saved query queryID of the project query
+// hierarchy and loads the first page of matching work items. Unless scope is
+// zero, the wiql of the query is read and run within scope.
+func FetchWorkItemsByQuery(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, queryID string, scope Scope) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch work items", Err: err}
+		}
 
-	var ids []string
-	for _, Item := range data.WorkItems {
-		ids = append(ids, fmt.Sprintf("%d", Item.ID))
+		if !scope.IsZero() {
+			queryUrl := azHttpClient.ProjectUrl("_apis/wit/queries/"+url.PathEscape(queryID), url.Values{"$expand": {"wiql"}})
+
+			query, err := azhttpclient.Get[workitems.QueryItem](ctx, azHttpClient, queryUrl)
+			if err != nil {
+				return models.ErrorMsg{Action: "could not fetch the saved query", Err: err}
+			}
+
+			return runWiql(ctx, azHttpClient, scope.Apply(query.Wiql))
+		}
+
+		queryUrl := azHttpClient.ProjectUrl("_apis/wit/wiql/"+url.PathEscape(queryID), nil)
+
+		data, err := azhttpclient.Get[WorkItemsResponse](ctx, azHttpClient, queryUrl)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not run the saved query", Err: err}
+		}
+
+		return fetchFirstPage(ctx, azHttpClient, data.IDs())
 	}
+}
 
-	totalWorkItemsUrl += strings.Join(ids, ",")
+func fetchFirstPage(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, ids []int) tea.Msg {
+	pager := azhttpclient.NewPager(azhttpclient.SlicePages(ids, pageSize, func(ctx context.Context, ids []int) ([]workitems.WorkItem, error) {
+		return FetchWorkItemsByIds(ctx, azHttpClient, ids)
+	}))
 
-	type Response struct {
-		Count int                  `json:"count"`
-		Value []workitems.WorkItem `json:"value"`
+	return fetchPage(ctx, pager, false)
+}
+
+// FetchMoreWorkItems loads the next page of pager, appended to the list.
+func FetchMoreWorkItems(ctx context.Context, pager *azhttpclient.Pager[workitems.WorkItem]) tea.Cmd {
+	return func() tea.Msg {
+		return fetchPage(ctx, pager, true)
 	}
+}
 
-	workItems, err := azhttpclient.Get[Response](azHttpClient, totalWorkItemsUrl)
+func fetchPage(ctx context.Context, pager *azhttpclient.Pager[workitems.WorkItem], appendItems bool) tea.Msg {
+	workItems, err := pager.Next(ctx)
 	if err != nil {
-		log.Fatalf("could not fetch work items: %v", err)
+		return models.ErrorMsg{Action: "could not fetch work items", Err: err}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return models.ErrorMsg{Action: "could not fetch work items", Err: err}
+	}
+
+	return WorkItemsResponseMsg{Items: workItems, Pager: pager, Append: appendItems}
+}
+
+const (
+	// batchSize is the maximum number of ids accepted by workitemsbatch.
+	batchSize        = 200
+	batchParallelism = 4
+	// pageSize is the number of work items shown before scrolling loads more.
+	pageSize = batchSize * batchParallelism
+)
+
+// batchPayload asks either for Fields or, with Expand, for every field and
+// the relations: the endpoint rejects both at once.
+type batchPayload struct {
+	IDs         []int    `json:"ids"`
+	Fields      []string `json:"fields,omitempty"`
+	Expand      string   `json:"$expand,omitempty"`
+	ErrorPolicy string   `json:"errorPolicy"`
+}
+
+// FetchWorkItemsByIds loads the fields listed in FieldNames for ids, in the
+// order of ids.
+func FetchWorkItemsByIds(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, ids []int) ([]workitems.WorkItem, error) {
+	return fetchBatches(ctx, azHttpClient, ids, batchPayload{Fields: workitems.FieldNames})
+}
+
+// fetchWorkItemsWithRelations loads ids with their relations.
+func fetchWorkItemsWithRelations(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, ids []int) ([]workitems.WorkItem, error) {
+	return fetchBatches(ctx, azHttpClient, ids, batchPayload{Expand: "relations"})
+}
+
+// fetchBatches loads ids through the workitemsbatch endpoint in chunks of
+// batchSize, a few chunks at a time, and returns the work items in the order
+// of ids. Ids that no longer exist or are not readable are skipped.
+func fetchBatches(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, ids []int, request batchPayload) ([]workitems.WorkItem, error) {
+	batchUrl := azHttpClient.ProjectUrl("_apis/wit/workitemsbatch", nil)
+
+	chunks := slices.Collect(slices.Chunk(ids, batchSize))
+	results := make([][]workitems.WorkItem, len(chunks))
+
+	type Response struct {
+		Count int                   `json:"count"`
+		Value []*workitems.WorkItem `json:"value"`
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(batchParallelism)
+
+	for i, chunk := range chunks {
+		group.Go(func() error {
+			payload := request
+			payload.IDs, payload.ErrorPolicy = chunk, "omit"
+
+			response, err := azhttpclient.Post[batchPayload, Response](ctx, azHttpClient, batchUrl, payload, azhttpclient.Idempotent())
+			if err != nil {
+				return err
+			}
+
+			byId := make(map[int]workitems.WorkItem, len(response.Value))
+			for _, item := range response.Value {
+				if item != nil {
+					byId[item.ID] = *item
+				}
+			}
+
+			for _, id := range chunk {
+				if item, ok := byId[id]; ok {
+					results[i] = append(results[i], item)
+				}
+			}
+
+			return nil
+		})
 	}
 
-	return WorkItemsResponseMsg(workItems.Value)
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(results...), nil
+}
+
+// WiqlValidatedMsg reports whether Wiql was accepted by the server. Err holds
+// the decoded syntax error otherwise.
+type WiqlValidatedMsg struct {
+	Wiql string
+	Err  error
+}
+
+// ValidateWiql runs wiql asking for a single result, so syntax and field errors
+// are reported without loading any work items.
+func ValidateWiql(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, wiql string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return WiqlValidatedMsg{Wiql: wiql, Err: err}
+		}
+
+		wiqlUrl := azHttpClient.ProjectUrl("_apis/wit/wiql", url.Values{"$top": {"1"}})
+
+		_, err := azhttpclient.Post[QueryPayload, WorkItemsResponse](ctx, azHttpClient, wiqlUrl, QueryPayload{Query: wiql}, azhttpclient.Idempotent())
+
+		return WiqlValidatedMsg{Wiql: wiql, Err: err}
+	}
 }
@@ -0,0 +1,47 @@
+package workitems
+
+import (
+	"context"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// QueryFolderMsg carries the children of the query folder ParentID, or the
+// root folders ("My Queries", "Shared Queries") when ParentID is empty, or the
+// error loading them.
+type QueryFolderMsg struct {
+	ParentID string
+	Items    []workitems.QueryItem
+	Err      error
+}
+
+// FetchQueryFolder loads one level of the query hierarchy below parentID.
+func FetchQueryFolder(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, parentID string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return QueryFolderMsg{ParentID: parentID, Err: err}
+		}
+
+		query := url.Values{"$depth": {"1"}, "$expand": {"wiql"}}
+
+		if parentID == "" {
+			folders, err := azhttpclient.Get[azhttpclient.ListResponse[workitems.QueryItem]](ctx, azHttpClient, azHttpClient.ProjectUrl("_apis/wit/queries", query))
+			if err != nil {
+				return QueryFolderMsg{Err: models.ErrorMsg{Action: "could not fetch queries", Err: err}}
+			}
+
+			return QueryFolderMsg{Items: folders.Value}
+		}
+
+		folder, err := azhttpclient.Get[workitems.QueryItem](ctx, azHttpClient, azHttpClient.ProjectUrl("_apis/wit/queries/"+url.PathEscape(parentID), query))
+		if err != nil {
+			return QueryFolderMsg{ParentID: parentID, Err: models.ErrorMsg{Action: "could not fetch queries", Err: err}}
+		}
+
+		return QueryFolderMsg{ParentID: parentID, Items: folder.Children}
+	}
+}
@@ -0,0 +1,46 @@
+package workitems
+
+import (
+	"context"
+	azhttpclient "lazyaz/internal/http"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// fetchWorkItemType loads the type called name with its states, transitions
+// and fields, including allowed values.
+func fetchWorkItemType(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, name string) (workitems.WorkItemType, error) {
+	typeUrl := "_apis/wit/workitemtypes/" + url.PathEscape(name)
+
+	var workItemType workitems.WorkItemType
+	var states azhttpclient.ListResponse[workitems.WorkItemState]
+	var fields azhttpclient.ListResponse[workitems.FieldInstance]
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() (err error) {
+		workItemType, err = azhttpclient.Get[workitems.WorkItemType](ctx, azHttpClient, azHttpClient.ProjectUrl(typeUrl, nil))
+		return err
+	})
+
+	group.Go(func() (err error) {
+		states, err = azhttpclient.Get[azhttpclient.ListResponse[workitems.WorkItemState]](ctx, azHttpClient, azHttpClient.ProjectUrl(typeUrl+"/states", nil))
+		return err
+	})
+
+	group.Go(func() (err error) {
+		fields, err = azhttpclient.Get[azhttpclient.ListResponse[workitems.FieldInstance]](ctx, azHttpClient, azHttpClient.ProjectUrl(typeUrl+"/fields", url.Values{"$expand": {"allowedValues"}}))
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		return workItemType, err
+	}
+
+	workItemType.States = states.Value
+	workItemType.Fields = fields.Value
+
+	return workItemType, nil
+}
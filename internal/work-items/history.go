@@ -0,0 +1,51 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const updatesPageSize = 200
+
+// HistoryMsg carries every update of Item, oldest first.
+type HistoryMsg struct {
+	Item    workitems.WorkItem
+	Updates []workitems.WorkItemUpdate
+}
+
+// FetchHistory loads all the updates of item.
+func FetchHistory(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the history", Err: err}
+		}
+
+		updates, err := FetchUpdates(ctx, azHttpClient, item.ID)
+		if err != nil {
+			return models.ErrorMsg{Action: fmt.Sprintf("could not fetch the history of #%d", item.ID), Err: err}
+		}
+
+		return HistoryMsg{Item: item, Updates: updates}
+	}
+}
+
+// FetchUpdates walks the pages of `_apis/wit/workItems/{id}/updates`.
+func FetchUpdates(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, id int) ([]workitems.WorkItemUpdate, error) {
+	updatesUrl := azHttpClient.ProjectUrl(fmt.Sprintf("_apis/wit/workItems/%d/updates", id), nil)
+	pager := azhttpclient.NewPager(azhttpclient.SkipTopPages[workitems.WorkItemUpdate](azHttpClient, updatesUrl, updatesPageSize))
+
+	var updates []workitems.WorkItemUpdate
+	for page, err := range pager.Pages(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, page...)
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,82 @@
+package workitems
+
+import (
+	"context"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IdentitiesMsg carries the people matching Query, or the error looking them
+// up.
+type IdentitiesMsg struct {
+	Query      string
+	Identities []workitems.Identity
+	Err        error
+}
+
+type identityQuery struct {
+	Query          string          `json:"query"`
+	IdentityTypes  []string        `json:"identityTypes"`
+	OperationScope []string        `json:"operationScopes"`
+	Properties     []string        `json:"properties"`
+	Options        identityOptions `json:"options"`
+}
+
+type identityOptions struct {
+	MinResults int `json:"MinResults"`
+	MaxResults int `json:"MaxResults"`
+}
+
+type identityResults struct {
+	Results []struct {
+		Identities []struct {
+			LocalID       string `json:"localId"`
+			DisplayName   string `json:"displayName"`
+			SignInAddress string `json:"signInAddress"`
+			Mail          string `json:"mail"`
+		} `json:"identities"`
+	} `json:"results"`
+}
+
+// SearchIdentities looks up the users of the organization whose name or email
+// starts with query, as the web @mention picker does.
+func SearchIdentities(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, query string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return IdentitiesMsg{Query: query, Err: err}
+		}
+
+		payload := identityQuery{
+			Query:          query,
+			IdentityTypes:  []string{"user"},
+			OperationScope: []string{"ims", "source"},
+			Properties:     []string{"DisplayName", "Mail", "SignInAddress"},
+			Options:        identityOptions{MinResults: 5, MaxResults: 10},
+		}
+
+		searchUrl := azHttpClient.OrganizationUrl("_apis/IdentityPicker/Identities", url.Values{"api-version": {azHttpClient.PreviewApiVersion(1)}})
+
+		results, err := azhttpclient.Post[identityQuery, identityResults](ctx, azHttpClient, searchUrl, payload, azhttpclient.Idempotent())
+		if err != nil {
+			return IdentitiesMsg{Query: query, Err: models.ErrorMsg{Action: "could not search people", Err: err}}
+		}
+
+		msg := IdentitiesMsg{Query: query}
+		for _, result := range results.Results {
+			for _, identity := range result.Identities {
+				uniqueName := identity.SignInAddress
+				if uniqueName == "" {
+					uniqueName = identity.Mail
+				}
+
+				msg.Identities = append(msg.Identities, workitems.Identity{ID: identity.LocalID, DisplayName: identity.DisplayName, UniqueName: uniqueName})
+			}
+		}
+
+		return msg
+	}
+}
@@ -0,0 +1,196 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PullRequestRelation is the name of the artifact link between a work item
+// and a pull request.
+const PullRequestRelation = "Pull Request"
+
+type RelationType struct {
+	ReferenceName string `json:"referenceName"`
+	Name          string `json:"name"`
+	Attributes    struct {
+		Usage   string `json:"usage"`
+		Enabled bool   `json:"enabled"`
+	} `json:"attributes"`
+}
+
+// LinkManagerMsg carries Item with its relations, the work items it links to
+// and the link types that can be added between work items.
+type LinkManagerMsg struct {
+	LinksMsg
+	Types []RelationType
+}
+
+// FetchLinkManager loads what the link manager shows for item.
+func FetchLinkManager(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem) tea.Cmd {
+	return func() tea.Msg {
+		msg := FetchLinks(ctx, azHttpClient, item)()
+
+		links, ok := msg.(LinksMsg)
+		if !ok {
+			return msg
+		}
+
+		typesUrl := azHttpClient.OrganizationUrl("_apis/wit/workitemrelationtypes", nil)
+
+		types, err := azhttpclient.Get[azhttpclient.ListResponse[RelationType]](ctx, azHttpClient, typesUrl)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not fetch the link types", Err: err}
+		}
+
+		manager := LinkManagerMsg{LinksMsg: links}
+		for _, relationType := range types.Value {
+			if relationType.Attributes.Usage == "workItemLink" && relationType.Attributes.Enabled {
+				manager.Types = append(manager.Types, relationType)
+			}
+		}
+
+		return manager
+	}
+}
+
+// LinkTarget is the other end of a new link: a work item or, for the pull
+// request relation, a pull request of the project.
+type LinkTarget struct {
+	Rel           string
+	WorkItemID    int
+	PullRequestID int
+	Comment       string
+}
+
+// AddLink links item to target.
+func AddLink(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem, target LinkTarget) tea.Cmd {
+	return func() tea.Msg {
+		add, err := linkOperation(ctx, azHttpClient, target)
+		if err != nil {
+			return WorkItemUpdateFailedMsg{Original: item, Err: err}
+		}
+
+		return UpdateWorkItem(ctx, azHttpClient, item, []azhttpclient.PatchOperation{TestRev(item.Rev), add})()
+	}
+}
+
+// RemoveLink removes the relation at index from item.
+func RemoveLink(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem, index int) tea.Cmd {
+	return UpdateWorkItem(ctx, azHttpClient, item, []azhttpclient.PatchOperation{TestRev(item.Rev), removeRelation(index)})
+}
+
+// RetargetLink replaces the relation at index with a link of the same type to
+// target, in a single revision.
+func RetargetLink(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem, index int, target LinkTarget) tea.Cmd {
+	return func() tea.Msg {
+		add, err := linkOperation(ctx, azHttpClient, target)
+		if err != nil {
+			return WorkItemUpdateFailedMsg{Original: item, Err: err}
+		}
+
+		operations := []azhttpclient.PatchOperation{TestRev(item.Rev), removeRelation(index), add}
+
+		return UpdateWorkItem(ctx, azHttpClient, item, operations)()
+	}
+}
+
+// TestRev returns the operation failing the patch when the work item is no
+// longer at revision rev.
+func TestRev(rev int) azhttpclient.PatchOperation {
+	return azhttpclient.PatchOperation{Op: "test", Path: "/rev", Value: rev}
+}
+
+func removeRelation(index int) azhttpclient.PatchOperation {
+	return azhttpclient.PatchOperation{Op: "remove", Path: fmt.Sprintf("/relations/%d", index)}
+}
+
+func linkOperation(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, target LinkTarget) (azhttpclient.PatchOperation, error) {
+	if target.PullRequestID == 0 {
+		return AddRelation(azHttpClient, target.Rel, target.WorkItemID, target.Comment), nil
+	}
+
+	artifactUrl, err := pullRequestArtifact(ctx, azHttpClient, target.PullRequestID)
+	if err != nil {
+		return azhttpclient.PatchOperation{}, models.ErrorMsg{Action: fmt.Sprintf("could not find pull request %d", target.PullRequestID), Err: err}
+	}
+
+	relation := map[string]any{
+		"rel":        "ArtifactLink",
+		"url":        artifactUrl,
+		"attributes": map[string]any{"name": PullRequestRelation, "comment": target.Comment},
+	}
+
+	return azhttpclient.PatchOperation{Op: "add", Path: "/relations/-", Value: relation}, nil
+}
+
+// pullRequestArtifact returns the vstfs:/// url identifying the pull request
+// id in links, made of its project and repository ids.
+func pullRequestArtifact(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, id int) (string, error) {
+	type pullRequest struct {
+		Repository struct {
+			ID      string `json:"id"`
+			Project struct {
+				ID string `json:"id"`
+			} `json:"project"`
+		} `json:"repository"`
+	}
+
+	pullRequestUrl := azHttpClient.ProjectUrl(fmt.Sprintf("_apis/git/pullrequests/%d", id), nil)
+
+	found, err := azhttpclient.Get[pullRequest](ctx, azHttpClient, pullRequestUrl)
+	if err != nil {
+		return "", err
+	}
+
+	artifactID := url.PathEscape(found.Repository.Project.ID + "/" + found.Repository.ID + "/" + fmt.Sprint(id))
+
+	return "vstfs:///Git/PullRequestId/" + artifactID, nil
+}
+
+// WorkItemSearchMsg carries the work items whose title contains Text.
+type WorkItemSearchMsg struct {
+	Text  string
+	Items []workitems.WorkItem
+}
+
+const searchLimit = 20
+
+// SearchWorkItems looks up the most recently changed work items of the
+// project whose title contains text.
+func SearchWorkItems(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not search work items", Err: err}
+		}
+
+		wiql := fmt.Sprintf(
+			"SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @Project AND [System.Title] CONTAINS '%s' ORDER BY [System.ChangedDate] DESC",
+			strings.ReplaceAll(text, "'", "''"),
+		)
+		wiqlUrl := azHttpClient.ProjectUrl("_apis/wit/wiql", url.Values{"$top": {fmt.Sprint(searchLimit)}})
+
+		data, err := azhttpclient.Post[QueryPayload, WorkItemsResponse](ctx, azHttpClient, wiqlUrl, QueryPayload{Query: wiql}, azhttpclient.Idempotent())
+		if err != nil {
+			return models.ErrorMsg{Action: "could not search work items", Err: err}
+		}
+
+		ids := data.IDs()
+		if len(ids) == 0 {
+			return WorkItemSearchMsg{Text: text}
+		}
+
+		items, err := FetchWorkItemsByIds(ctx, azHttpClient, ids)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not search work items", Err: err}
+		}
+
+		return WorkItemSearchMsg{Text: text, Items: items}
+	}
+}
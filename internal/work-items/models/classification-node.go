@@ -0,0 +1,38 @@
+package workitems
+
+import "strings"
+
+// ClassificationNode is an area or iteration of the project.
+type ClassificationNode struct {
+	ID          int                  `json:"id"`
+	Identifier  string               `json:"identifier"`
+	Name        string               `json:"name"`
+	Path        string               `json:"path"`
+	HasChildren bool                 `json:"hasChildren"`
+	Children    []ClassificationNode `json:"children"`
+	Attributes  struct {
+		StartDate  string `json:"startDate"`
+		FinishDate string `json:"finishDate"`
+	} `json:"attributes"`
+}
+
+// FieldPath returns the path as stored in System.AreaPath and
+// System.IterationPath, e.g. "Project\Sprint 1" for "\Project\Iteration\Sprint 1".
+func (n ClassificationNode) FieldPath() string {
+	parts := strings.Split(strings.TrimPrefix(n.Path, `\`), `\`)
+	if len(parts) < 2 {
+		return strings.Join(parts, `\`)
+	}
+
+	return strings.Join(append(parts[:1], parts[2:]...), `\`)
+}
+
+// FieldPaths returns the field path of the node and of all its descendants.
+func (n ClassificationNode) FieldPaths() []string {
+	paths := []string{n.FieldPath()}
+	for _, child := range n.Children {
+		paths = append(paths, child.FieldPaths()...)
+	}
+
+	return paths
+}
@@ -0,0 +1,76 @@
+package workitems
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+)
+
+type Comment struct {
+	ID           int      `json:"id"`
+	WorkItemID   int      `json:"workItemId"`
+	Version      int      `json:"version"`
+	Text         string   `json:"text"`
+	CreatedBy    Identity `json:"createdBy"`
+	CreatedDate  string   `json:"createdDate"`
+	ModifiedDate string   `json:"modifiedDate"`
+	IsDeleted    bool     `json:"isDeleted"`
+}
+
+type CommentList struct {
+	TotalCount        int       `json:"totalCount"`
+	Count             int       `json:"count"`
+	Comments          []Comment `json:"comments"`
+	ContinuationToken string    `json:"continuationToken"`
+}
+
+// TextMarkdown converts the HTML text of the comment into Markdown.
+func (c Comment) TextMarkdown() (string, error) {
+	converter := md.NewConverter("", true, &md.Options{})
+	return converter.ConvertString(c.Text)
+}
+
+// CommentsMarkdown renders comments as the Markdown section shown below the
+// work item preview. total is the number of comments of the work item, which
+// may be more than the ones loaded.
+func CommentsMarkdown(comments []Comment, total int) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Comments (%d)\n\n", total)
+	if len(comments) < total {
+		fmt.Fprintf(&b, "_showing the latest %d_\n\n", len(comments))
+	}
+
+	for _, comment := range comments {
+		if comment.IsDeleted {
+			continue
+		}
+
+		text, err := comment.TextMarkdown()
+		if err != nil {
+			text = comment.Text
+		}
+
+		fmt.Fprintf(&b, "**%s** · %s\n\n%s\n\n---\n\n", comment.CreatedBy.DisplayName, formatDate(comment.CreatedDate), text)
+	}
+
+	return b.String()
+}
+
+// MentionHtml returns the markup Azure DevOps uses for an @mention of the
+// identity, which notifies the person mentioned.
+func MentionHtml(identity Identity) string {
+	return fmt.Sprintf(`<a href="#" data-vss-mention="version:2.0,%s">@%s</a>`, identity.ID, html.EscapeString(identity.DisplayName))
+}
+
+// formatDate shortens an ISO 8601 timestamp to its date and minutes.
+func formatDate(date string) string {
+	date = strings.Replace(date, "T", " ", 1)
+	if len(date) > 16 {
+		return date[:16]
+	}
+
+	return date
+}
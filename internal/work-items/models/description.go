@@ -0,0 +1,34 @@
+package workitems
+
+import (
+	"bytes"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// DescriptionMarkdown converts the HTML System.Description into Markdown.
+func (i WorkItem) DescriptionMarkdown() (string, error) {
+	var description string
+
+	if i.Fields.Description != nil {
+		description = *i.Fields.Description
+	}
+
+	converter := md.NewConverter("", true, &md.Options{})
+	return converter.ConvertString(description)
+}
+
+// MarkdownToHtml converts Markdown written by the user into the HTML stored
+// in rich text fields such as System.Description.
+func MarkdownToHtml(markdown string) (string, error) {
+	var html bytes.Buffer
+
+	converter := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := converter.Convert([]byte(markdown), &html); err != nil {
+		return "", err
+	}
+
+	return html.String(), nil
+}
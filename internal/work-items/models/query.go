@@ -0,0 +1,20 @@
+package workitems
+
+// QueryItem is a folder or a saved query of the project query hierarchy.
+type QueryItem struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Path        string      `json:"path"`
+	IsFolder    bool        `json:"isFolder"`
+	HasChildren bool        `json:"hasChildren"`
+	IsPublic    bool        `json:"isPublic"`
+	QueryType   string      `json:"queryType"`
+	Wiql        string      `json:"wiql"`
+	Children    []QueryItem `json:"children"`
+}
+
+// ChildrenLoaded reports whether the children of a folder came with it or
+// still have to be requested.
+func (q QueryItem) ChildrenLoaded() bool {
+	return !q.HasChildren || len(q.Children) > 0
+}
@@ -0,0 +1,147 @@
+package workitems
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Relation links a work item to another work item or to an artifact such as a
+// pull request, a commit or a build.
+type Relation struct {
+	Rel        string         `json:"rel"`
+	URL        string         `json:"url"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+const (
+	ParentRelation = "System.LinkTypes.Hierarchy-Reverse"
+	ChildRelation  = "System.LinkTypes.Hierarchy-Forward"
+)
+
+var relationNames = map[string]string{
+	"System.LinkTypes.Hierarchy-Reverse":     "Parent",
+	"System.LinkTypes.Hierarchy-Forward":     "Child",
+	"System.LinkTypes.Related":               "Related",
+	"System.LinkTypes.Duplicate-Forward":     "Duplicate",
+	"System.LinkTypes.Duplicate-Reverse":     "Duplicate Of",
+	"System.LinkTypes.Dependency-Forward":    "Successor",
+	"System.LinkTypes.Dependency-Reverse":    "Predecessor",
+	"Microsoft.VSTS.Common.Affects-Forward":  "Affects",
+	"Microsoft.VSTS.Common.Affects-Reverse":  "Affected By",
+	"Microsoft.VSTS.Common.TestedBy-Forward": "Tested By",
+	"Microsoft.VSTS.Common.TestedBy-Reverse": "Tests",
+	"ArtifactLink":                           "Artifact",
+	"Hyperlink":                              "Hyperlink",
+	"AttachedFile":                           "Attachment",
+}
+
+// Name returns the name of the relation type as shown in the web UI, or the
+// name attribute the server sets on artifact links.
+func (r Relation) Name() string {
+	if name, ok := r.Attributes["name"].(string); ok && name != "" {
+		return name
+	}
+
+	if name, ok := relationNames[r.Rel]; ok {
+		return name
+	}
+
+	return r.Rel
+}
+
+// WorkItemID returns the id of the linked work item, or false when the
+// relation points to something else.
+func (r Relation) WorkItemID() (int, bool) {
+	if !strings.Contains(strings.ToLower(r.URL), "/_apis/wit/workitems/") {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(path.Base(r.URL))
+
+	return id, err == nil
+}
+
+// Artifact describes what an artifact link points to, such as "Pull Request
+// 42" or "Fixed in Commit 1a2b3c4", from its vstfs:/// url.
+func (r Relation) Artifact() string {
+	if r.Rel != "ArtifactLink" {
+		return r.URL
+	}
+
+	id, err := url.PathUnescape(path.Base(r.URL))
+	if err != nil {
+		id = path.Base(r.URL)
+	}
+	if slash := strings.LastIndex(id, "/"); slash >= 0 {
+		id = id[slash+1:]
+	}
+
+	if strings.Contains(r.URL, "/Git/Commit/") && len(id) > 7 {
+		id = id[:7]
+	}
+
+	return r.Name() + " " + id
+}
+
+// ParentID returns the id of the parent of the work item, loaded with its relations.
+func (i WorkItem) ParentID() (int, bool) {
+	for _, relation := range i.Relations {
+		if relation.Rel == ParentRelation {
+			return relation.WorkItemID()
+		}
+	}
+
+	return 0, false
+}
+
+// ChildIDs returns the ids of the children of the work item, in link order.
+func (i WorkItem) ChildIDs() []int {
+	var ids []int
+	for _, relation := range i.Relations {
+		if relation.Rel != ChildRelation {
+			continue
+		}
+
+		if id, ok := relation.WorkItemID(); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// LinkedIDs returns the ids of every work item linked to the work item.
+func (i WorkItem) LinkedIDs() []int {
+	var ids []int
+	for _, relation := range i.Relations {
+		if id, ok := relation.WorkItemID(); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// LinksMarkdown renders the relations of the work item as the Markdown list
+// shown in the preview.
+func (i WorkItem) LinksMarkdown() string {
+	if len(i.Relations) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("## Links\n\n")
+
+	for _, relation := range i.Relations {
+		if id, ok := relation.WorkItemID(); ok {
+			fmt.Fprintf(&b, "- %s #%d\n", relation.Name(), id)
+		} else {
+			fmt.Fprintf(&b, "- %s\n", relation.Artifact())
+		}
+	}
+
+	return b.String()
+}
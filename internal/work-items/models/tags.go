@@ -0,0 +1,53 @@
+package workitems
+
+import (
+	"strings"
+)
+
+// TagList returns the tags of the work item, which System.Tags holds
+// separated by semicolons.
+func (i WorkItem) TagList() []string {
+	var tags []string
+	for _, tag := range strings.Split(i.Fields.Tags, ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// JoinTags returns tags as the value of System.Tags.
+func JoinTags(tags []string) string {
+	return strings.Join(tags, "; ")
+}
+
+// TagFilter keeps the work items having all the Include tags, or any of them
+// when Any is set, and none of the Exclude tags.
+type TagFilter struct {
+	Include []string
+	Exclude []string
+	Any     bool
+}
+
+func (f TagFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+func (f TagFilter) String() string {
+	var terms []string
+
+	separator := " & "
+	if f.Any {
+		separator = " | "
+	}
+	if len(f.Include) > 0 {
+		terms = append(terms, strings.Join(f.Include, separator))
+	}
+
+	for _, tag := range f.Exclude {
+		terms = append(terms, "-"+tag)
+	}
+
+	return strings.Join(terms, " ")
+}
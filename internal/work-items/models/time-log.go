@@ -0,0 +1,58 @@
+package workitems
+
+import (
+	"slices"
+	"time"
+)
+
+// TimeEntry is the time logged on a work item on Day, by the people who
+// logged it.
+type TimeEntry struct {
+	Day    time.Time
+	Hours  float64
+	People []string
+}
+
+// DailyLog sums the increments of CompletedWork in updates by day, oldest day first.
+func DailyLog(updates []WorkItemUpdate) []TimeEntry {
+	var entries []TimeEntry
+
+	for _, update := range updates {
+		change, ok := update.Fields[CompletedWorkField]
+		if !ok {
+			continue
+		}
+
+		newValue, _ := change.NewValue.(float64)
+		oldValue, _ := change.OldValue.(float64)
+		if newValue == oldValue {
+			continue
+		}
+
+		changedAt := update.ChangedAt().Local()
+		day := time.Date(changedAt.Year(), changedAt.Month(), changedAt.Day(), 0, 0, 0, 0, time.Local)
+
+		if len(entries) == 0 || !entries[len(entries)-1].Day.Equal(day) {
+			entries = append(entries, TimeEntry{Day: day})
+		}
+
+		entry := &entries[len(entries)-1]
+		entry.Hours += newValue - oldValue
+
+		name := update.RevisedBy.DisplayName
+		if !slices.Contains(entry.People, name) {
+			entry.People = append(entry.People, name)
+		}
+	}
+
+	return entries
+}
+
+// LogTime returns the work item with hours moved from its remaining work,
+// which does not go below zero, to its completed work.
+func (i WorkItem) LogTime(hours float64) WorkItem {
+	i.Fields.RemainingWork = max(0, i.Fields.RemainingWork-hours)
+	i.Fields.CompletedWork += hours
+
+	return i
+}
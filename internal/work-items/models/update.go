@@ -0,0 +1,134 @@
+package workitems
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+)
+
+// WorkItemUpdate is one revision of a work item, with the fields and links it changed.
+type WorkItemUpdate struct {
+	ID          int                    `json:"id"`
+	WorkItemID  int                    `json:"workItemId"`
+	Rev         int                    `json:"rev"`
+	RevisedBy   Identity               `json:"revisedBy"`
+	RevisedDate string                 `json:"revisedDate"`
+	Fields      map[string]FieldChange `json:"fields"`
+	Relations   *RelationChanges       `json:"relations"`
+}
+
+type FieldChange struct {
+	OldValue any `json:"oldValue"`
+	NewValue any `json:"newValue"`
+}
+
+type RelationChanges struct {
+	Added   []Relation `json:"added"`
+	Removed []Relation `json:"removed"`
+	Updated []Relation `json:"updated"`
+}
+
+// bookkeepingFields change with every revision and are left out of the history.
+var bookkeepingFields = map[string]bool{
+	"System.Rev":            true,
+	"System.ChangedDate":    true,
+	"System.ChangedBy":      true,
+	"System.AuthorizedDate": true,
+	"System.AuthorizedAs":   true,
+	"System.RevisedDate":    true,
+	"System.Watermark":      true,
+	"System.PersonId":       true,
+	"System.AreaId":         true,
+	"System.IterationId":    true,
+	"System.NodeName":       true,
+	"System.CommentCount":   true,
+}
+
+// richTextFields hold HTML, shown and diffed as Markdown.
+var richTextFields = map[string]bool{
+	"System.Description":                       true,
+	"System.History":                           true,
+	"Microsoft.VSTS.TCM.ReproSteps":            true,
+	"Microsoft.VSTS.Common.AcceptanceCriteria": true,
+	"Microsoft.VSTS.TCM.SystemInfo":            true,
+	"Microsoft.VSTS.Common.Resolution":         true,
+}
+
+// ChangedDate returns when the update was made. RevisedDate is when the
+// revision was superseded, far in the future for the latest one.
+func (u WorkItemUpdate) ChangedDate() string {
+	if change, ok := u.Fields["System.ChangedDate"]; ok && change.NewValue != nil {
+		return formatDate(fmt.Sprint(change.NewValue))
+	}
+
+	return formatDate(u.RevisedDate)
+}
+
+// ChangedAt is ChangedDate as a time, the zero time when it cannot be parsed.
+func (u WorkItemUpdate) ChangedAt() time.Time {
+	date := u.RevisedDate
+	if change, ok := u.Fields["System.ChangedDate"]; ok && change.NewValue != nil {
+		date = fmt.Sprint(change.NewValue)
+	}
+
+	changedAt, _ := time.Parse(time.RFC3339Nano, date)
+	return changedAt
+}
+
+// NumberChange returns the new value of the numeric field referenceName, 0
+// when it was cleared, and whether the update changed it.
+func (u WorkItemUpdate) NumberChange(referenceName string) (float64, bool) {
+	change, ok := u.Fields[referenceName]
+	if !ok {
+		return 0, false
+	}
+
+	value, _ := change.NewValue.(float64)
+	return value, true
+}
+
+// ChangedFields returns the reference names of the fields changed by the
+// update, leaving out the ones every revision changes.
+func (u WorkItemUpdate) ChangedFields() []string {
+	var names []string
+	for name := range u.Fields {
+		if !bookkeepingFields[name] {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// IsRichText tells whether the field referenceName holds HTML.
+func IsRichText(referenceName string) bool {
+	return richTextFields[referenceName]
+}
+
+// FormatValue renders a field value from an update: identities by their
+// display name and HTML as Markdown.
+func FormatValue(referenceName string, value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case map[string]any:
+		if name, ok := value["displayName"]; ok {
+			return fmt.Sprint(name)
+		}
+	case string:
+		if IsRichText(referenceName) {
+			converter := md.NewConverter("", true, &md.Options{})
+			if markdown, err := converter.ConvertString(value); err == nil {
+				return markdown
+			}
+		}
+
+		return strings.TrimSpace(value)
+	case float64:
+		return fmt.Sprintf("%g", value)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,70 @@
+package workitems
+
+// WorkItemType describes a work item type of the project process, with the
+// transitions allowed from each state.
+type WorkItemType struct {
+	Name          string                  `json:"name"`
+	ReferenceName string                  `json:"referenceName"`
+	Color         string                  `json:"color"`
+	IsDisabled    bool                    `json:"isDisabled"`
+	States        []WorkItemState         `json:"states"`
+	Transitions   map[string][]Transition `json:"transitions"`
+	Fields        []FieldInstance         `json:"fields"`
+}
+
+type WorkItemState struct {
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Category string `json:"category"`
+}
+
+type Transition struct {
+	To      string   `json:"to"`
+	Actions []string `json:"actions"`
+}
+
+// FieldInstance is a field as configured on a work item type.
+type FieldInstance struct {
+	ReferenceName  string `json:"referenceName"`
+	Name           string `json:"name"`
+	AlwaysRequired bool   `json:"alwaysRequired"`
+	DefaultValue   any    `json:"defaultValue"`
+	AllowedValues  []any  `json:"allowedValues"`
+	HelpText       string `json:"helpText"`
+}
+
+// NextStates returns the states a work item in state from can move to. When
+// the process does not expose transitions every other state is allowed.
+func (t WorkItemType) NextStates(from string) []WorkItemState {
+	byName := make(map[string]WorkItemState, len(t.States))
+	for _, state := range t.States {
+		byName[state.Name] = state
+	}
+
+	transitions, ok := t.Transitions[from]
+	if !ok {
+		var states []WorkItemState
+		for _, state := range t.States {
+			if state.Name != from {
+				states = append(states, state)
+			}
+		}
+
+		return states
+	}
+
+	var states []WorkItemState
+	for _, transition := range transitions {
+		if transition.To == from {
+			continue
+		}
+
+		state, ok := byName[transition.To]
+		if !ok {
+			state = WorkItemState{Name: transition.To}
+		}
+		states = append(states, state)
+	}
+
+	return states
+}
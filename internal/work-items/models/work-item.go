@@ -2,19 +2,18 @@ package workitems
 
 import (
 	"fmt"
-	"log"
+	"strconv"
 	"strings"
 
-	md "github.com/JohannesKaufmann/html-to-markdown"
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/glamour"
 )
 
 type WorkItem struct {
-	ID     int    `json:"id"`
-	Rev    int    `json:"rev"`
-	Fields Fields `json:"fields"`
-	URL    string `json:"url"`
+	ID        int        `json:"id"`
+	Rev       int        `json:"rev"`
+	Fields    Fields     `json:"fields"`
+	Relations []Relation `json:"relations"`
+	URL       string     `json:"url"`
 }
 
 func (i WorkItem) Title() string {
@@ -34,29 +33,16 @@ func (i WorkItem) GetID() int {
 }
 
 func (i WorkItem) GetURL() string {
-	url := strings.Replace(i.URL, "_apis/wit/workItems", "_workitems/edit", 1)
-	if err := clipboard.WriteAll(url); err != nil {
-		log.Fatalf("Failed to copy to clipboard: %v", err)
-	}
-
-	return url
+	return strings.Replace(i.URL, "_apis/wit/workItems", "_workitems/edit", 1)
 }
 
 func (i WorkItem) GetPreview(renderer *glamour.TermRenderer) string {
-	var description string
-
-	if i.Fields.Description == nil {
-		description = ""
-	} else {
-		description = *i.Fields.Description
-	}
-	converter := md.NewConverter("", true, &md.Options{})
-	markdownDescription, err := converter.ConvertString(description)
+	markdownDescription, err := i.DescriptionMarkdown()
 	if err != nil {
 		fmt.Println("Error converting HTML to Markdown:", err)
 	}
 
-	markdownContent := fmt.Sprintf("%d\n# %s\n---\n%s\n- URL: %s\n", i.ID, i.Fields.Title, markdownDescription, i.URL)
+	markdownContent := fmt.Sprintf("%d\n# %s\n---\n%s\n- URL: %s\n\n%s", i.ID, i.Fields.Title, markdownDescription, i.URL, i.LinksMarkdown())
 	rendered, _ := renderer.Render(markdownContent)
 	return rendered
 }
@@ -67,6 +53,7 @@ type Fields struct {
 	IterationPath                string   `json:"System.IterationPath"`
 	WorkItemType                 string   `json:"System.WorkItemType"`
 	State                        string   `json:"System.State"`
+	BoardColumn                  string   `json:"System.BoardColumn"`
 	Reason                       string   `json:"System.Reason"`
 	AssignedTo                   Identity `json:"System.AssignedTo"`
 	CreatedDate                  string   `json:"System.CreatedDate"`
@@ -76,10 +63,79 @@ type Fields struct {
 	CommentCount                 int      `json:"System.CommentCount"`
 	Title                        string   `json:"System.Title"`
 	Description                  *string  `json:"System.Description"`
+	Tags                         string   `json:"System.Tags"`
 	MicrosoftVSTSCommonPriority  int      `json:"Microsoft.VSTS.Common.Priority"`
 	MicrosoftVSTSStateChangeDate string   `json:"Microsoft.VSTS.Common.StateChangeDate"`
 	MicrosoftVSTSActivatedDate   string   `json:"Microsoft.VSTS.Common.ActivatedDate"`
 	MicrosoftVSTSActivatedBy     Identity `json:"Microsoft.VSTS.Common.ActivatedBy"`
+	RemainingWork                float64  `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
+	CompletedWork                float64  `json:"Microsoft.VSTS.Scheduling.CompletedWork"`
+}
+
+// FieldValue returns the value of the field referenceName as it is edited in
+// forms. Only the fields decoded into Fields are supported.
+func (i WorkItem) FieldValue(referenceName string) string {
+	switch referenceName {
+	case "System.Title":
+		return i.Fields.Title
+	case "System.AssignedTo":
+		return i.Fields.AssignedTo.UniqueName
+	case "System.State":
+		return i.Fields.State
+	case "System.Reason":
+		return i.Fields.Reason
+	case "System.IterationPath":
+		return i.Fields.IterationPath
+	case "System.AreaPath":
+		return i.Fields.AreaPath
+	case "System.Tags":
+		return i.Fields.Tags
+	case "System.Description":
+		markdown, _ := i.DescriptionMarkdown()
+		return markdown
+	case "Microsoft.VSTS.Common.Priority":
+		if i.Fields.MicrosoftVSTSCommonPriority == 0 {
+			return ""
+		}
+		return strconv.Itoa(i.Fields.MicrosoftVSTSCommonPriority)
+	}
+
+	return ""
+}
+
+// RemainingWorkField and CompletedWorkField hold the hours left and spent on
+// a work item, usually a Task.
+const (
+	RemainingWorkField = "Microsoft.VSTS.Scheduling.RemainingWork"
+	CompletedWorkField = "Microsoft.VSTS.Scheduling.CompletedWork"
+)
+
+// FieldNames lists the fields decoded into Fields, requested explicitly when
+// work items are loaded in batches.
+var FieldNames = []string{
+	"System.Id",
+	"System.AreaPath",
+	"System.TeamProject",
+	"System.IterationPath",
+	"System.WorkItemType",
+	"System.State",
+	"System.BoardColumn",
+	"System.Reason",
+	"System.AssignedTo",
+	"System.CreatedDate",
+	"System.CreatedBy",
+	"System.ChangedDate",
+	"System.ChangedBy",
+	"System.CommentCount",
+	"System.Title",
+	"System.Description",
+	"System.Tags",
+	"Microsoft.VSTS.Common.Priority",
+	"Microsoft.VSTS.Common.StateChangeDate",
+	"Microsoft.VSTS.Common.ActivatedDate",
+	"Microsoft.VSTS.Common.ActivatedBy",
+	RemainingWorkField,
+	CompletedWorkField,
 }
 
 type Identity struct {
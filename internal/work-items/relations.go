@@ -0,0 +1,97 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RelationsMsg carries work items loaded with their relations, the children
+// of ParentID or, when it is zero, the listed work items.
+type RelationsMsg struct {
+	ParentID int
+	Items    []workitems.WorkItem
+}
+
+// FetchRelations loads ids with their relations, to be shown as children of
+// parentID.
+func FetchRelations(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, parentID int, ids []int) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch links", Err: err}
+		}
+
+		items, err := fetchWorkItemsWithRelations(ctx, azHttpClient, ids)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not fetch links", Err: err}
+		}
+
+		return RelationsMsg{ParentID: parentID, Items: items}
+	}
+}
+
+// LinksMsg carries Item with its relations and the work items it links to.
+type LinksMsg struct {
+	Item   workitems.WorkItem
+	Linked []workitems.WorkItem
+}
+
+// FetchLinks loads the relations of item and the work items they point to.
+func FetchLinks(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch links", Err: err}
+		}
+
+		items, err := fetchWorkItemsWithRelations(ctx, azHttpClient, []int{item.ID})
+		if err != nil {
+			return models.ErrorMsg{Action: fmt.Sprintf("could not fetch the links of #%d", item.ID), Err: err}
+		}
+
+		if len(items) == 0 {
+			return models.ErrorMsg{Action: fmt.Sprintf("could not fetch the links of #%d", item.ID), Err: fmt.Errorf("work item not found")}
+		}
+
+		msg := LinksMsg{Item: items[0]}
+		if ids := msg.Item.LinkedIDs(); len(ids) > 0 {
+			msg.Linked, err = fetchWorkItemsWithRelations(ctx, azHttpClient, ids)
+			if err != nil {
+				return models.ErrorMsg{Action: fmt.Sprintf("could not fetch the links of #%d", item.ID), Err: err}
+			}
+		}
+
+		return msg
+	}
+}
+
+// PreviewLinksMsg carries the relations of the work item ID, or the error
+// loading them.
+type PreviewLinksMsg struct {
+	ID        int
+	Relations []workitems.Relation
+	Err       error
+}
+
+// FetchPreviewLinks loads the relations of the work item id, for the preview
+// of a work item listed without them.
+func FetchPreviewLinks(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, id int) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return PreviewLinksMsg{ID: id, Err: err}
+		}
+
+		items, err := fetchWorkItemsWithRelations(ctx, azHttpClient, []int{id})
+		if err == nil && len(items) == 0 {
+			err = fmt.Errorf("work item not found")
+		}
+		if err != nil {
+			return PreviewLinksMsg{ID: id, Err: models.ErrorMsg{Action: fmt.Sprintf("could not fetch the links of #%d", id), Err: err}}
+		}
+
+		return PreviewLinksMsg{ID: id, Relations: items[0].Relations}
+	}
+}
@@ -0,0 +1,116 @@
+package workitems
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items/models"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	whereClause   = regexp.MustCompile(`(?i)\bWHERE\b`)
+	trailerClause = regexp.MustCompile(`(?i)\b(ORDER\s+BY|MODE\s*\(|ASOF)\b`)
+	linksSource   = regexp.MustCompile(`(?i)\bFROM\s+WorkItemLinks\b`)
+)
+
+// Scope narrows the work items of a query to those under IterationPath and
+// matching Tags, when they are set.
+type Scope struct {
+	IterationPath string
+	Tags          workitems.TagFilter
+}
+
+func (s Scope) IsZero() bool {
+	return s.IterationPath == "" && s.Tags.IsZero()
+}
+
+// Apply adds the conditions of the scope to wiql. Link queries are scoped by
+// their source work items. wiql is returned as is when the scope is zero.
+func (s Scope) Apply(wiql string) string {
+	if s.IsZero() {
+		return wiql
+	}
+
+	links := linksSource.MatchString(mask(wiql))
+	field := func(referenceName string) string {
+		if links {
+			return "[Source].[" + referenceName + "]"
+		}
+		return "[" + referenceName + "]"
+	}
+
+	var conditions []string
+	if s.IterationPath != "" {
+		conditions = append(conditions, fmt.Sprintf("%s UNDER %s", field("System.IterationPath"), quote(s.IterationPath)))
+	}
+
+	var included []string
+	for _, tag := range s.Tags.Include {
+		included = append(included, fmt.Sprintf("%s CONTAINS %s", field("System.Tags"), quote(tag)))
+	}
+	if len(included) > 1 && s.Tags.Any {
+		conditions = append(conditions, "("+strings.Join(included, " OR ")+")")
+	} else {
+		conditions = append(conditions, included...)
+	}
+
+	for _, tag := range s.Tags.Exclude {
+		conditions = append(conditions, fmt.Sprintf("%s NOT CONTAINS %s", field("System.Tags"), quote(tag)))
+	}
+
+	return addConditions(wiql, strings.Join(conditions, " AND "))
+}
+
+// addConditions ANDs conditions with the WHERE clause of wiql, keeping its
+// ORDER BY, MODE and ASOF clauses after them. The clauses are looked up in
+// the masked wiql so words in literals and field names are not taken for
+// them.
+func addConditions(wiql string, conditions string) string {
+	masked := mask(wiql)
+
+	end, trailer := len(wiql), ""
+	if at := trailerClause.FindStringIndex(masked); at != nil {
+		end, trailer = at[0], " "+wiql[at[0]:]
+	}
+	body := strings.TrimRightFunc(wiql[:end], unicode.IsSpace)
+
+	at := whereClause.FindStringIndex(masked[:len(body)])
+	if at == nil {
+		return body + " WHERE " + conditions + trailer
+	}
+
+	return fmt.Sprintf("%s WHERE (%s) AND %s%s", strings.TrimSpace(body[:at[0]]), strings.TrimSpace(body[at[1]:]), conditions, trailer)
+}
+
+// mask blanks the string literals and the bracketed names of wiql, keeping
+// its length so the positions found in it hold for wiql.
+func mask(wiql string) string {
+	masked := []byte(wiql)
+
+	var closing byte
+	for i := 0; i < len(masked); i++ {
+		c := masked[i]
+		switch {
+		case closing == 0 && (c == '\'' || c == '"'):
+			closing = c
+		case closing == 0 && c == '[':
+			closing = ']'
+		case closing == 0:
+		case c == closing && closing != ']' && i+1 < len(masked) && masked[i+1] == closing:
+			// A doubled quote is a quote within the literal.
+			masked[i], masked[i+1] = ' ', ' '
+			i++
+		case c == closing:
+			closing = 0
+		default:
+			masked[i] = ' '
+		}
+	}
+
+	return string(masked)
+}
+
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,122 @@
+package workitems
+
+import (
+	workitems "lazyaz/internal/work-items/models"
+	"testing"
+)
+
+func TestScopeApply(t *testing.T) {
+	sprint := Scope{IterationPath: `Fabrikam\Sprint 1`}
+
+	tests := []struct {
+		name  string
+		scope Scope
+		wiql  string
+		want  string
+	}{
+		{
+			name:  "zero scope",
+			scope: Scope{},
+			wiql:  "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active'",
+			want:  "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active'",
+		},
+		{
+			name:  "flat query",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active' OR [System.State] = 'New'",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE ([System.State] = 'Active' OR [System.State] = 'New') AND [System.IterationPath] UNDER 'Fabrikam\Sprint 1'`,
+		},
+		{
+			name:  "missing where",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItems",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE [System.IterationPath] UNDER 'Fabrikam\Sprint 1'`,
+		},
+		{
+			name:  "missing where with order by",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItems ORDER BY [System.ChangedDate] DESC",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE [System.IterationPath] UNDER 'Fabrikam\Sprint 1' ORDER BY [System.ChangedDate] DESC`,
+		},
+		{
+			name:  "order by",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItems WHERE [System.State] <> 'Closed'\nORDER BY [System.Id]",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE ([System.State] <> 'Closed') AND [System.IterationPath] UNDER 'Fabrikam\Sprint 1' ORDER BY [System.Id]`,
+		},
+		{
+			name:  "asof",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active' ASOF '2024-01-01T00:00:00Z'",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE ([System.State] = 'Active') AND [System.IterationPath] UNDER 'Fabrikam\Sprint 1' ASOF '2024-01-01T00:00:00Z'`,
+		},
+		{
+			name:  "link query",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItemLinks WHERE [Source].[System.WorkItemType] = 'Feature' AND [System.Links.LinkType] = 'System.LinkTypes.Related' MODE (MustContain)",
+			want:  `SELECT [System.Id] FROM WorkItemLinks WHERE ([Source].[System.WorkItemType] = 'Feature' AND [System.Links.LinkType] = 'System.LinkTypes.Related') AND [Source].[System.IterationPath] UNDER 'Fabrikam\Sprint 1' MODE (MustContain)`,
+		},
+		{
+			name:  "tree query",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM workitemLinks WHERE [System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Forward' ORDER BY [System.Id] MODE (Recursive)",
+			want:  `SELECT [System.Id] FROM workitemLinks WHERE ([System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Forward') AND [Source].[System.IterationPath] UNDER 'Fabrikam\Sprint 1' ORDER BY [System.Id] MODE (Recursive)`,
+		},
+		{
+			name:  "keywords in literals",
+			scope: sprint,
+			wiql:  "SELECT [System.Id] FROM WorkItems WHERE [System.Title] = 'where to order by mode (x) asof' AND [System.Tags] CONTAINS 'it''s where'",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE ([System.Title] = 'where to order by mode (x) asof' AND [System.Tags] CONTAINS 'it''s where') AND [System.IterationPath] UNDER 'Fabrikam\Sprint 1'`,
+		},
+		{
+			name:  "keywords in double quoted literals and field names",
+			scope: sprint,
+			wiql:  `SELECT [Custom.Where] FROM WorkItems WHERE [Custom.Order By] = "FROM WorkItemLinks ""where"""`,
+			want:  `SELECT [Custom.Where] FROM WorkItems WHERE ([Custom.Order By] = "FROM WorkItemLinks ""where""") AND [System.IterationPath] UNDER 'Fabrikam\Sprint 1'`,
+		},
+		{
+			name:  "quotes in the scope",
+			scope: Scope{IterationPath: `Fabrikam\Team's sprint`, Tags: workitems.TagFilter{Include: []string{"don't"}}},
+			wiql:  "SELECT [System.Id] FROM WorkItems",
+			want:  `SELECT [System.Id] FROM WorkItems WHERE [System.IterationPath] UNDER 'Fabrikam\Team''s sprint' AND [System.Tags] CONTAINS 'don''t'`,
+		},
+		{
+			name:  "all tags",
+			scope: Scope{Tags: workitems.TagFilter{Include: []string{"api", "ui"}}},
+			wiql:  "SELECT [System.Id] FROM WorkItems",
+			want:  "SELECT [System.Id] FROM WorkItems WHERE [System.Tags] CONTAINS 'api' AND [System.Tags] CONTAINS 'ui'",
+		},
+		{
+			name:  "any tag",
+			scope: Scope{Tags: workitems.TagFilter{Include: []string{"api", "ui"}, Any: true}},
+			wiql:  "SELECT [System.Id] FROM WorkItems",
+			want:  "SELECT [System.Id] FROM WorkItems WHERE ([System.Tags] CONTAINS 'api' OR [System.Tags] CONTAINS 'ui')",
+		},
+		{
+			name:  "any of one tag",
+			scope: Scope{Tags: workitems.TagFilter{Include: []string{"api"}, Any: true}},
+			wiql:  "SELECT [System.Id] FROM WorkItems",
+			want:  "SELECT [System.Id] FROM WorkItems WHERE [System.Tags] CONTAINS 'api'",
+		},
+		{
+			name:  "excluded tags",
+			scope: Scope{Tags: workitems.TagFilter{Include: []string{"api", "ui"}, Exclude: []string{"blocked"}, Any: true}},
+			wiql:  "SELECT [System.Id] FROM WorkItems WHERE [System.State] = 'Active'",
+			want:  "SELECT [System.Id] FROM WorkItems WHERE ([System.State] = 'Active') AND ([System.Tags] CONTAINS 'api' OR [System.Tags] CONTAINS 'ui') AND [System.Tags] NOT CONTAINS 'blocked'",
+		},
+		{
+			name:  "tags of a link query",
+			scope: Scope{IterationPath: "Fabrikam", Tags: workitems.TagFilter{Exclude: []string{"blocked"}}},
+			wiql:  "SELECT [System.Id] FROM WorkItemLinks WHERE [System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Forward' MODE (Recursive)",
+			want:  "SELECT [System.Id] FROM WorkItemLinks WHERE ([System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Forward') AND [Source].[System.IterationPath] UNDER 'Fabrikam' AND [Source].[System.Tags] NOT CONTAINS 'blocked' MODE (Recursive)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.scope.Apply(test.wiql); got != test.want {
+				t.Errorf("Apply(%q)\n got %s\nwant %s", test.wiql, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package workitems
+
+import (
+	"context"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	"net/url"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const tagsApiVersion = 1
+
+// TagsMsg carries the tags used in the project, sorted by name, or the error
+// loading them.
+type TagsMsg struct {
+	Tags []string
+	Err  error
+}
+
+// FetchTags loads the tags of the project, offered when tagging work items.
+func FetchTags(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return TagsMsg{Err: err}
+		}
+
+		type tag struct {
+			Name string `json:"name"`
+		}
+
+		tagsUrl := azHttpClient.ProjectUrl("_apis/wit/tags", url.Values{"api-version": {azHttpClient.PreviewApiVersion(tagsApiVersion)}})
+
+		response, err := azhttpclient.Get[azhttpclient.ListResponse[tag]](ctx, azHttpClient, tagsUrl)
+		if err != nil {
+			return TagsMsg{Err: models.ErrorMsg{Action: "could not fetch the tags", Err: err}}
+		}
+
+		var tags []string
+		for _, tag := range response.Value {
+			tags = append(tags, tag.Name)
+		}
+		slices.SortFunc(tags, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+
+		return TagsMsg{Tags: tags}
+	}
+}
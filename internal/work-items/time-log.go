@@ -0,0 +1,62 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TimeLogMsg carries Item with the time logged on it each day.
+type TimeLogMsg struct {
+	Item    workitems.WorkItem
+	Entries []workitems.TimeEntry
+}
+
+// FetchTimeLog loads the updates of item and sums the time logged on it by day.
+func FetchTimeLog(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the logged time", Err: err}
+		}
+
+		updates, err := FetchUpdates(ctx, azHttpClient, item.ID)
+		if err != nil {
+			return models.ErrorMsg{Action: fmt.Sprintf("could not fetch the logged time of #%d", item.ID), Err: err}
+		}
+
+		return TimeLogMsg{Item: item, Entries: workitems.DailyLog(updates)}
+	}
+}
+
+// LogTime moves hours from the remaining work of original to its completed
+// work. The hours are applied to the values on the server, read again first,
+// so time logged meanwhile by somebody else is not lost.
+func LogTime(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, original workitems.WorkItem, hours float64) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return WorkItemUpdateFailedMsg{Original: original, Err: err}
+		}
+
+		current, err := fetchWorkItem(ctx, azHttpClient, original.ID)
+		if err != nil {
+			return WorkItemUpdateFailedMsg{Original: original, Err: models.ErrorMsg{Action: fmt.Sprintf("could not log time on #%d", original.ID), Err: err}}
+		}
+
+		operations := append([]azhttpclient.PatchOperation{TestRev(current.Rev)}, logTimeOperations(current, hours)...)
+
+		return UpdateWorkItem(ctx, azHttpClient, original, operations)()
+	}
+}
+
+func logTimeOperations(item workitems.WorkItem, hours float64) []azhttpclient.PatchOperation {
+	logged := item.LogTime(hours)
+
+	return []azhttpclient.PatchOperation{
+		SetField(workitems.RemainingWorkField, logged.Fields.RemainingWork),
+		SetField(workitems.CompletedWorkField, logged.Fields.CompletedWork),
+	}
+}
@@ -0,0 +1,123 @@
+package workitems
+
+import (
+	"context"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items/models"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WorkItemUpdatedMsg carries the work item returned by the server after a
+// successful update, with its new revision.
+type WorkItemUpdatedMsg struct {
+	Item workitems.WorkItem
+}
+
+// WorkItemUpdateFailedMsg reports a rejected update. Original is the work item
+// as it was before the update was applied optimistically.
+type WorkItemUpdateFailedMsg struct {
+	Original workitems.WorkItem
+	Err      error
+}
+
+// UpdateWorkItem applies operations to original as a JSON Patch document. The
+// updated work item is returned with its relations.
+func UpdateWorkItem(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, original workitems.WorkItem, operations []azhttpclient.PatchOperation) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return WorkItemUpdateFailedMsg{Original: original, Err: err}
+		}
+
+		updateUrl := azHttpClient.ProjectUrl(fmt.Sprintf("_apis/wit/workitems/%d", original.ID), url.Values{"$expand": {"relations"}})
+
+		item, err := azhttpclient.Patch[[]azhttpclient.PatchOperation, workitems.WorkItem](ctx, azHttpClient, updateUrl, operations)
+		if err != nil {
+			return WorkItemUpdateFailedMsg{Original: original, Err: models.ErrorMsg{Action: fmt.Sprintf("could not update work item %d", original.ID), Err: err}}
+		}
+
+		return WorkItemUpdatedMsg{Item: item}
+	}
+}
+
+// SetField returns the operation setting the field referenceName to value.
+func SetField(referenceName string, value any) azhttpclient.PatchOperation {
+	return azhttpclient.PatchOperation{Op: "add", Path: "/fields/" + referenceName, Value: value}
+}
+
+// StateTransitionsMsg lists the states item can move to.
+type StateTransitionsMsg struct {
+	Item       workitems.WorkItem
+	NextStates []workitems.WorkItemState
+}
+
+// FetchStateTransitions loads the states allowed after the current state of item.
+func FetchStateTransitions(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, item workitems.WorkItem) tea.Cmd {
+	return func() tea.Msg {
+		if err := azHttpClient.ValidatePat(); err != nil {
+			return models.ErrorMsg{Action: "could not fetch the allowed states", Err: err}
+		}
+
+		workItemType, err := fetchWorkItemType(ctx, azHttpClient, item.Fields.WorkItemType)
+		if err != nil {
+			return models.ErrorMsg{Action: "could not fetch the allowed states", Err: err}
+		}
+
+		return StateTransitionsMsg{Item: item, NextStates: workItemType.NextStates(item.Fields.State)}
+	}
+}
+
+// ChangeState returns the operations moving a work item to state. The reason
+// is left to the server, which sets the default reason of the transition:
+// the allowed reasons depend on the transition, which the work item type
+// does not describe.
+func ChangeState(state string) []azhttpclient.PatchOperation {
+	return []azhttpclient.PatchOperation{SetField("System.State", state)}
+}
+
+// FieldEdit is a new value for the field ReferenceName.
+type FieldEdit struct {
+	ReferenceName string
+	Value         any
+}
+
+// WorkItemConflictMsg reports edits rejected because the work item changed on
+// the server since Original was read. Current is the work item as it is now.
+type WorkItemConflictMsg struct {
+	Original workitems.WorkItem
+	Current  workitems.WorkItem
+	Edits    []FieldEdit
+}
+
+// EditFields applies edits to original, guarded by a test of its revision so
+// changes made by somebody else in the meantime are not overwritten silently.
+func EditFields(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, original workitems.WorkItem, edits []FieldEdit) tea.Cmd {
+	return func() tea.Msg {
+		operations := []azhttpclient.PatchOperation{TestRev(original.Rev)}
+		for _, edit := range edits {
+			operations = append(operations, SetField(edit.ReferenceName, edit.Value))
+		}
+
+		msg := UpdateWorkItem(ctx, azHttpClient, original, operations)()
+
+		failed, ok := msg.(WorkItemUpdateFailedMsg)
+		if !ok || !azhttpclient.IsConflict(failed.Err) {
+			return msg
+		}
+
+		current, err := fetchWorkItem(ctx, azHttpClient, original.ID)
+		if err != nil {
+			return failed
+		}
+
+		return WorkItemConflictMsg{Original: original, Current: current, Edits: edits}
+	}
+}
+
+func fetchWorkItem(ctx context.Context, azHttpClient *azhttpclient.AzHttpClient, id int) (workitems.WorkItem, error) {
+	itemUrl := azHttpClient.ProjectUrl(fmt.Sprintf("_apis/wit/workitems/%d", id), nil)
+	return azhttpclient.Get[workitems.WorkItem](ctx, azHttpClient, itemUrl)
+}
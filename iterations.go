@@ -0,0 +1,38 @@
+package main
+
+import (
+	iterations "lazyaz/internal/iterations/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type iterationChosenMsg iterations.Iteration
+
+// newIterationPicker lists the sprints of the team, the current one marked,
+// after an entry removing the iteration scope.
+func newIterationPicker(all []iterations.Iteration, active iterations.Iteration) Picker {
+	items := []PickerItem{{Name: "All iterations", Details: "do not scope work items to a sprint", Value: iterations.Iteration{}}}
+	selected := 0
+
+	for _, iteration := range all {
+		details := iteration.Dates()
+		name := iteration.Name
+		if iteration.IsCurrent() {
+			name += " ★"
+			details += " · current sprint"
+			if active.ID == "" {
+				selected = len(items)
+			}
+		}
+
+		if iteration.ID == active.ID {
+			selected = len(items)
+		}
+
+		items = append(items, PickerItem{Name: name, Details: iteration.Path + " · " + details, Value: iteration})
+	}
+
+	return NewPicker("Iterations", items, selected, func(item PickerItem) tea.Msg {
+		return iterationChosenMsg(item.Value.(iterations.Iteration))
+	})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type jumpMsg workitemsmodels.WorkItem
+
+func newJumpPicker(msg workitems.LinksMsg) Picker {
+	linked := make(map[int]workitemsmodels.WorkItem, len(msg.Linked))
+	for _, item := range msg.Linked {
+		linked[item.ID] = item
+	}
+
+	var items []PickerItem
+	for _, relation := range msg.Item.Relations {
+		id, ok := relation.WorkItemID()
+		if !ok {
+			continue
+		}
+
+		item, ok := linked[id]
+		if !ok {
+			continue
+		}
+
+		details := fmt.Sprintf("%s · %s · %s", relation.Name(), item.Fields.WorkItemType, item.Fields.State)
+		items = append(items, PickerItem{Name: item.Title(), Details: details, Value: item})
+	}
+
+	return NewPicker(fmt.Sprintf("Jump from #%d to", msg.Item.ID), items, 0, func(item PickerItem) tea.Msg {
+		return jumpMsg(item.Value.(workitemsmodels.WorkItem))
+	})
+}
+
+// jumpTo selects item in the list, adding it next to the selected item when
+// the query did not return it.
+func (m *Model) jumpTo(item workitemsmodels.WorkItem) tea.Cmd {
+	m.status = fmt.Sprintf("jumped to #%d", item.ID)
+
+	for index, listed := range m.list.Items() {
+		if listed, ok := listed.(workitemsmodels.WorkItem); ok && listed.ID == item.ID {
+			m.list.Select(index)
+			return m.refreshPreview()
+		}
+	}
+
+	if m.tree != nil {
+		m.tree.addRoot(item)
+		cmd := m.list.SetItems(m.tree.rows())
+		m.list.Select(0)
+		return tea.Batch(cmd, m.refreshPreview())
+	}
+
+	index := m.list.Index() + 1
+	cmd := m.list.InsertItem(index, item)
+	m.list.Select(index)
+
+	return tea.Batch(cmd, m.refreshPreview())
+}
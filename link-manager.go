@@ -0,0 +1,354 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// linkChangedMsg asks to add a link to Item when Index is -1, or to remove
+// the relation at Index, or to retarget it when Target is set.
+type linkChangedMsg struct {
+	Item   workitemsmodels.WorkItem
+	Index  int
+	Target *workitems.LinkTarget
+}
+
+// changeLink sends the link change described by msg.
+func (m *Model) changeLink(msg linkChangedMsg) tea.Cmd {
+	ctx := context.Background()
+
+	switch {
+	case msg.Target == nil:
+		m.status = fmt.Sprintf("removing a link of #%d…", msg.Item.ID)
+		return workitems.RemoveLink(ctx, m.client, msg.Item, msg.Index)
+	case msg.Index < 0:
+		m.status = fmt.Sprintf("linking #%d…", msg.Item.ID)
+		return workitems.AddLink(ctx, m.client, msg.Item, *msg.Target)
+	default:
+		m.status = fmt.Sprintf("retargeting a link of #%d…", msg.Item.ID)
+		return workitems.RetargetLink(ctx, m.client, msg.Item, msg.Index, *msg.Target)
+	}
+}
+
+type linkRow struct {
+	relation workitemsmodels.Relation
+	index    int
+	linked   *workitemsmodels.WorkItem
+}
+
+func (r linkRow) Title() string {
+	if r.linked != nil {
+		return r.relation.Name() + " → " + r.linked.Title()
+	}
+
+	if id, ok := r.relation.WorkItemID(); ok {
+		return fmt.Sprintf("%s → #%d", r.relation.Name(), id)
+	}
+
+	return r.relation.Artifact()
+}
+
+func (r linkRow) Description() string {
+	comment, _ := r.relation.Attributes["comment"].(string)
+	if r.linked != nil {
+		return strings.TrimSpace(fmt.Sprintf("%s · %s %s", r.linked.Fields.WorkItemType, r.linked.Fields.State, comment))
+	}
+
+	return comment
+}
+
+func (r linkRow) FilterValue() string { return r.Title() }
+
+// LinkManager lists the links of a work item and adds, removes or retargets
+// them. New links point to a work item, given by id or found by title, or to
+// a pull request.
+type LinkManager struct {
+	item       workitemsmodels.WorkItem
+	types      []workitems.RelationType
+	list       list.Model
+	form       *Form
+	retarget   *linkRow
+	confirming bool
+	results    []workitemsmodels.WorkItem
+	result     int
+	searching  bool
+	err        error
+	search     func(text string) tea.Cmd
+}
+
+func newLinkManager(msg workitems.LinkManagerMsg, search func(text string) tea.Cmd) LinkManager {
+	linked := make(map[int]workitemsmodels.WorkItem, len(msg.Linked))
+	for _, item := range msg.Linked {
+		linked[item.ID] = item
+	}
+
+	var rows []list.Item
+	for index, relation := range msg.Item.Relations {
+		row := linkRow{relation: relation, index: index}
+		if id, ok := relation.WorkItemID(); ok {
+			if item, ok := linked[id]; ok {
+				row.linked = &item
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	l := list.New(rows, list.NewDefaultDelegate(), 70, 16)
+	l.Title = fmt.Sprintf("Links of #%d %s", msg.Item.ID, msg.Item.Fields.Title)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+
+	return LinkManager{item: msg.Item, types: msg.Types, list: l, search: search}
+}
+
+func (d LinkManager) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workitems.WorkItemSearchMsg:
+		if d.form == nil {
+			return d, nil
+		}
+
+		d.searching = false
+		d.results, d.result = msg.Items, 0
+		d.err = nil
+		if len(msg.Items) == 0 {
+			d.err = fmt.Errorf("no work item title contains %q", msg.Text)
+			return d, nil
+		}
+
+		d.chooseResult(0)
+		return d, nil
+
+	case tea.KeyMsg:
+		if d.form != nil {
+			return d.updateForm(msg)
+		}
+
+		if d.confirming {
+			d.confirming = false
+			row, ok := d.list.SelectedItem().(linkRow)
+			if msg.String() != "y" || !ok {
+				return d, nil
+			}
+
+			removed := linkChangedMsg{Item: d.item, Index: row.index}
+			return d, tea.Sequence(closeDialog, func() tea.Msg { return removed })
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return d, closeDialog
+		case "a":
+			return d, d.openForm(nil)
+		case "d":
+			if _, ok := d.list.SelectedItem().(linkRow); ok {
+				d.confirming = true
+			}
+			return d, nil
+		case "t":
+			row, ok := d.list.SelectedItem().(linkRow)
+			if !ok {
+				return d, nil
+			}
+
+			if _, ok := row.relation.WorkItemID(); !ok {
+				d.err = fmt.Errorf("only links to work items can be retargeted")
+				return d, nil
+			}
+
+			return d, d.openForm(&row)
+		}
+	}
+
+	var cmd tea.Cmd
+	d.list, cmd = d.list.Update(msg)
+
+	return d, cmd
+}
+
+// openForm asks for a new link, or for the new target of row.
+func (d *LinkManager) openForm(row *linkRow) tea.Cmd {
+	var names []string
+	for _, relationType := range d.types {
+		names = append(names, relationType.Name)
+	}
+	names = append(names, workitems.PullRequestRelation)
+
+	var fields []FormField
+	if row == nil {
+		fields = append(fields, NewFormField("Link Type", "type", "").WithSuggestions(names))
+	}
+	fields = append(fields,
+		NewFormField("Target", "target", ""),
+		NewFormField("Comment", "comment", ""),
+	)
+
+	form, cmd := NewForm(fields...)
+	d.form, d.retarget, d.err = &form, row, nil
+	d.results = nil
+
+	return cmd
+}
+
+func (d LinkManager) updateForm(msg tea.KeyMsg) (Dialog, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		d.form, d.retarget, d.results, d.err = nil, nil, nil, nil
+		return d, nil
+	case "ctrl+f":
+		text := d.formValue("target")
+		if text == "" || d.searching {
+			return d, nil
+		}
+
+		d.searching, d.err = true, nil
+		return d, d.search(text)
+	case "ctrl+n":
+		if len(d.results) > 0 {
+			d.chooseResult((d.result + 1) % len(d.results))
+			return d, nil
+		}
+	case "ctrl+p":
+		if len(d.results) > 0 {
+			d.chooseResult((d.result - 1 + len(d.results)) % len(d.results))
+			return d, nil
+		}
+	case "enter", "ctrl+s":
+		target, err := d.target()
+		if err != nil {
+			d.err = err
+			return d, nil
+		}
+
+		changed := linkChangedMsg{Item: d.item, Index: -1, Target: &target}
+		if d.retarget != nil {
+			changed.Index = d.retarget.index
+		}
+
+		return d, tea.Sequence(closeDialog, func() tea.Msg { return changed })
+	}
+
+	form, cmd := d.form.Update(msg)
+	d.form = &form
+
+	return d, cmd
+}
+
+// chooseResult fills the target with the search result at index.
+func (d *LinkManager) chooseResult(index int) {
+	d.result = index
+	for i, field := range d.form.Fields {
+		if field.ReferenceName == "target" {
+			d.form.Fields[i].input.SetValue(fmt.Sprintf("#%d", d.results[index].ID))
+		}
+	}
+}
+
+func (d LinkManager) formValue(referenceName string) string {
+	for _, field := range d.form.Fields {
+		if field.ReferenceName == referenceName {
+			return field.Value()
+		}
+	}
+
+	return ""
+}
+
+// target reads the link described by the form.
+func (d LinkManager) target() (workitems.LinkTarget, error) {
+	target := workitems.LinkTarget{Comment: d.formValue("comment")}
+
+	if d.retarget != nil {
+		target.Rel = d.retarget.relation.Rel
+	} else {
+		name := d.formValue("type")
+		for _, relationType := range d.types {
+			if strings.EqualFold(relationType.Name, name) || relationType.ReferenceName == name {
+				target.Rel = relationType.ReferenceName
+			}
+		}
+
+		if strings.EqualFold(name, workitems.PullRequestRelation) {
+			target.Rel = workitems.PullRequestRelation
+		}
+
+		if target.Rel == "" {
+			return target, fmt.Errorf("unknown link type %q", name)
+		}
+	}
+
+	id, err := strconv.Atoi(strings.TrimLeft(d.formValue("target"), "#!"))
+	if err != nil || id <= 0 {
+		return target, fmt.Errorf("the target must be an id, or ctrl+f to search titles")
+	}
+
+	if target.Rel == workitems.PullRequestRelation {
+		target.PullRequestID = id
+	} else {
+		target.WorkItemID = id
+	}
+
+	if target.WorkItemID == d.item.ID {
+		return target, fmt.Errorf("a work item cannot be linked to itself")
+	}
+
+	return target, nil
+}
+
+func (d LinkManager) View() string {
+	if d.form == nil {
+		lines := []string{d.list.View()}
+		if len(d.list.Items()) == 0 {
+			lines = []string{d.list.Title, "", "No links"}
+		}
+
+		if d.err != nil {
+			lines = append(lines, errorStyle.UnsetPadding().Render(d.err.Error()))
+		}
+
+		if d.confirming {
+			lines = append(lines, "Remove the selected link? y/n")
+		} else {
+			lines = append(lines, helpStyle.Render("a add · d remove · t retarget · esc close"))
+		}
+
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	title := fmt.Sprintf("New link from #%d", d.item.ID)
+	if d.retarget != nil {
+		title = fmt.Sprintf("Retarget %s", d.retarget.Title())
+	}
+
+	lines := []string{title, "", d.form.View(), ""}
+
+	switch {
+	case d.searching:
+		lines = append(lines, helpStyle.Render("Searching…"))
+	case len(d.results) > 0:
+		for i, item := range d.results {
+			line := "  " + item.Title()
+			if i == d.result {
+				line = selectedSuggestionStyle.Render("› " + item.Title())
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if d.err != nil {
+		lines = append(lines, errorStyle.UnsetPadding().Render(d.err.Error()))
+	}
+
+	lines = append(lines, helpStyle.Render("target: #id, a pull request id or text · ctrl+f search titles · ctrl+n/ctrl+p results · enter save · esc back"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
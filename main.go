@@ -1,11 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"lazyaz/internal/boards"
+	"lazyaz/internal/config"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/iterations"
+	iterationsmodels "lazyaz/internal/iterations/models"
 	"lazyaz/internal/models"
 	pullrequests "lazyaz/internal/pull-requests"
 	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/atotto/clipboard"
@@ -63,7 +73,7 @@ var (
 
 	activeTab = tabBase.Border(activeTabBorder, true).Foreground(normal).Bold(true)
 
-	tabs = []string{"(W) Work Items", "(P) Pull Requests"}
+	tabs = []string{"(W) Work Items", "(P) Pull Requests", "(S) Board", "(C) Burndown"}
 )
 
 type Model struct {
@@ -75,10 +85,44 @@ type Model struct {
 	selectedItem int
 	renderer     *glamour.TermRenderer
 	tabIndex     int
+	config       config.Config
+	configPath   string
+	profile      config.Profile
+	query        config.Query
+	// iteration scopes the Work Items tab and the board when it is set.
+	iteration iterationsmodels.Iteration
+	// tagFilter narrows the Work Items tab to tag combinations when it is set.
+	tagFilter workitemsmodels.TagFilter
+	client    *azhttpclient.AzHttpClient
+	// switchingTo is the profile whose PAT is being resolved to switch to it.
+	switchingTo string
+	dialog      Dialog
+	err         error
+	status      string
+	retries     chan azhttpclient.RetryEvent
+	fetchCtx    context.Context
+	cancelFetch context.CancelFunc
+	loadMore    func(ctx context.Context) tea.Cmd
+	loadingMore bool
+	// comments and links cache what the preview loaded for each work item,
+	// reset on refetch.
+	comments       map[int]workitems.CommentsMsg
+	links          map[int]workitems.PreviewLinksMsg
+	previewPending int
+	// tree arranges the Work Items list by links while it is shown as a tree,
+	// flatItems holding the query results to go back to.
+	tree      *workItemTree
+	flatItems []list.Item
+	board     *BoardView
+	burndown  *BurndownView
 }
 
-func initialModel() Model {
-	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+// loadMoreThreshold is how close to the end of the list the cursor gets before
+// the next page is requested.
+const loadMoreThreshold = 5
+
+func initialModel(cfg config.Config, configPath string, profile config.Profile) Model {
+	l := list.New([]list.Item{}, newWorkItemDelegate(), 0, 0)
 	l.Title = "Work Items"
 	l.SetShowStatusBar(false)
 	l.SetShowTitle(false)
@@ -96,36 +140,125 @@ func initialModel() Model {
 		glamour.WithWordWrap(80),
 	)
 
-	return Model{
+	m := Model{
 		list:       l,
 		preview:    vp,
 		activePane: 0,
 		renderer:   renderer,
 		tabIndex:   0,
+		config:     cfg,
+		configPath: configPath,
+		profile:    profile,
+		query:      profile.Views()[0],
+		retries:    make(chan azhttpclient.RetryEvent, 16),
+		comments:   make(map[int]workitems.CommentsMsg),
+		links:      make(map[int]workitems.PreviewLinksMsg),
 	}
+	m.client = m.watchRetries(azhttpclient.NewAzHttpClient(profile))
+	m.fetchCtx, m.cancelFetch = context.WithCancel(context.Background())
+
+	return m
+}
+
+// watchRetries forwards the retries of client to the status line.
+func (m Model) watchRetries(client *azhttpclient.AzHttpClient) *azhttpclient.AzHttpClient {
+	client.SetRetryNotifier(func(event azhttpclient.RetryEvent) {
+		select {
+		case m.retries <- event:
+		default:
+		}
+	})
+
+	return client
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(textinput.Blink, workitems.FetchWorkItems)
+	return tea.Batch(textinput.Blink, m.fetchTab(), waitForRetry(m.retries))
+}
+
+// fetchTab returns the command loading the items of the active tab.
+func (m Model) fetchTab() tea.Cmd {
+	switch m.tabIndex {
+	case 1:
+		return pullrequests.FetchPullRequests(m.fetchCtx, m.client, m.profile.Repositories)
+	case 2:
+		return boards.FetchBoard(m.fetchCtx, m.client, m.iteration)
+	case 3:
+		return iterations.FetchBurndown(m.fetchCtx, m.client, m.iteration)
+	default:
+		scope := workitems.Scope{IterationPath: m.iteration.Path, Tags: m.tagFilter}
+		if m.query.ID != "" {
+			return workitems.FetchWorkItemsByQuery(m.fetchCtx, m.client, m.query.ID, scope)
+		}
+
+		return workitems.FetchWorkItems(m.fetchCtx, m.client, scope.Apply(m.query.Wiql))
+	}
+}
+
+// refetch cancels the in-flight fetch, if any, and loads the active tab again.
+func (m *Model) refetch() tea.Cmd {
+	m.cancelFetch()
+	m.fetchCtx, m.cancelFetch = context.WithCancel(context.Background())
+	m.loadMore, m.loadingMore = nil, false
+	m.comments, m.links, m.previewPending = make(map[int]workitems.CommentsMsg), make(map[int]workitems.PreviewLinksMsg), 0
+
+	return m.fetchTab()
 }
 
-func handleResponseMsg[T list.Item](m *Model, msg []T) tea.Cmd {
+// handleResponseMsg shows a page of items, replacing the list or appending to
+// it, and remembers how to load the page after it.
+func handleResponseMsg[T list.Item](m *Model, msg []T, pager *azhttpclient.Pager[T], appendItems bool, fetchMore func(context.Context, *azhttpclient.Pager[T]) tea.Cmd) tea.Cmd {
 	var items []list.Item
 
-	m.list.SetItems([]list.Item{})
-	m.list.ResetFilter()
+	if appendItems {
+		items = m.list.Items()
+	} else {
+		if m.tree != nil {
+			m.closeTree()
+		}
+		m.list.SetItems([]list.Item{})
+		m.list.ResetFilter()
+	}
 
 	for _, item := range msg {
 		items = append(items, item)
 	}
 	cmd := m.list.SetItems(items)
 
-	if i, ok := m.list.SelectedItem().(models.UiItem); ok {
-		m.preview.SetContent(i.GetPreview(m.renderer))
-		m.selectedItem = i.GetID()
+	m.loadMore, m.loadingMore = nil, false
+	if pager.HasMore() {
+		m.loadMore = func(ctx context.Context) tea.Cmd {
+			return fetchMore(ctx, pager)
+		}
+	}
+
+	return tea.Batch(cmd, m.refreshPreview())
+}
+
+// replaceItem swaps the listed item with the same id as item, keeping its
+// position, and refreshes the preview when it is the selected one.
+func (m *Model) replaceItem(item models.UiItem) {
+	if workItem, ok := item.(workitemsmodels.WorkItem); ok && m.board != nil {
+		m.board.replace(workItem)
+	}
+
+	if workItem, ok := item.(workitemsmodels.WorkItem); ok && m.tree != nil {
+		m.tree.replace(workItem)
+		if replaced, ok := m.tree.items[workItem.ID]; ok {
+			item = replaced
+		}
+	}
+
+	for index, listed := range m.list.Items() {
+		if listed, ok := listed.(models.UiItem); ok && listed.GetID() == item.GetID() {
+			m.list.SetItem(index, item)
+			break
+		}
 	}
 
-	return cmd
+	if item.GetID() == m.selectedItem {
+		m.renderPreview()
+	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -138,51 +271,413 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		horizontalMargin := 4
 		listWidth := (m.width / 2) - horizontalMargin
 		previewWidth := m.width - listWidth - horizontalMargin*2
-		m.list.SetSize(listWidth, m.height-4)
+		m.list.SetSize(listWidth, m.height-5)
 		m.preview.Width = previewWidth
-		m.preview.Height = m.height - 4
+		m.preview.Height = m.height - 5
+
+	case closeDialogMsg:
+		m.dialog = nil
+		return m, nil
+
+	case models.ErrorMsg:
+		if errors.Is(msg, context.Canceled) {
+			return m, nil
+		}
+
+		m.err = msg
+		m.status = ""
+		m.loadingMore = false
+		if m.tree != nil {
+			clear(m.tree.loading)
+		}
+		m.list.StopSpinner()
+		return m, nil
+
+	case retryMsg:
+		m.status = retryStatus(azhttpclient.RetryEvent(msg))
+		return m, waitForRetry(m.retries)
+
+	case profileSelectedMsg:
+		if err := config.Profile(msg).Validate(); err != nil {
+			m.err = err
+			return m, nil
+		}
+
+		m.switchingTo = msg.Name
+		m.status = fmt.Sprintf("switching to %s…", msg.Name)
+		m.err = nil
+		return m, resolvePat(config.Profile(msg))
+
+	case patResolvedMsg:
+		if msg.Profile.Name != m.switchingTo {
+			return m, nil
+		}
+
+		m.switchingTo = ""
+		m.status = ""
+		m.profile = msg.Profile
+		m.query = m.profile.Views()[0]
+		m.iteration = iterationsmodels.Iteration{}
+		m.tagFilter = workitemsmodels.TagFilter{}
+		m.client = m.watchRetries(azhttpclient.NewAzHttpClientWithPat(msg.Profile, msg.Pat, msg.Err))
+		return m, m.refetch()
+
+	case iterations.IterationsMsg:
+		m.status = ""
+		m.dialog = newIterationPicker(msg.Iterations, m.iteration)
+		return m, nil
+
+	case tagFilterChosenMsg:
+		m.tagFilter = workitemsmodels.TagFilter(msg)
+		m.tabIndex = 0
+		m.err = nil
+		return m, m.refetch()
+
+	case iterationChosenMsg:
+		m.iteration = iterationsmodels.Iteration(msg)
+		m.err = nil
+		return m, m.refetch()
+
+	case querySelectedMsg:
+		m.query = config.Query(msg)
+		m.tabIndex = 0
+		m.err = nil
+		return m, m.refetch()
+
+	case viewSavedMsg:
+		query := config.Query(msg)
+		m.config.AddQuery(m.profile.Name, query)
+		m.profile.Queries = append(slices.Clone(m.profile.Queries), query)
+		m.query = query
+		m.tabIndex = 0
+		m.err = nil
+		return m, tea.Batch(m.refetch(), saveView(m.configPath, m.profile.Name, query))
+
+	case workitems.StateTransitionsMsg:
+		m.status = ""
+		if len(msg.NextStates) == 0 {
+			m.status = fmt.Sprintf("#%d cannot leave the %s state", msg.Item.ID, msg.Item.Fields.State)
+			return m, nil
+		}
+
+		m.dialog = newStatePicker(msg)
+		return m, nil
+
+	case stateChosenMsg:
+		return m, m.changeState(msg)
+
+	case workitems.WorkItemUpdatedMsg:
+		m.replaceItem(msg.Item)
+		m.status = fmt.Sprintf("updated #%d (rev %d)", msg.Item.ID, msg.Item.Rev)
+		return m, nil
+
+	case workitems.WorkItemUpdateFailedMsg:
+		m.replaceItem(msg.Original)
+		m.err, m.status = msg.Err, ""
+		return m, nil
+
+	case workitems.TimeLogMsg:
+		m.status = ""
+		logger, cmd := newTimeLogger(msg)
+		m.dialog = logger
+		return m, cmd
+
+	case timeLoggedMsg:
+		return m, m.logTime(msg)
+
+	case fieldsEditedMsg:
+		m.status = fmt.Sprintf("saving #%d…", msg.Item.ID)
+		return m, workitems.EditFields(context.Background(), m.client, msg.Item, msg.Edits)
+
+	case descriptionEditedMsg:
+		return m, m.saveDescription(msg)
+
+	case workitems.WorkItemConflictMsg:
+		m.status = ""
+		m.dialog = ConflictDialog{conflict: msg}
+		return m, nil
+
+	case workitems.WorkItemTypesMsg:
+		m.status = ""
+		m.dialog = newTypePicker(msg)
+		return m, nil
+
+	case typeChosenMsg:
+		m.status = fmt.Sprintf("loading the %s form…", string(msg))
+		return m, workitems.FetchCreationForm(m.fetchCtx, m.client, string(msg))
+
+	case workitems.CreationFormMsg:
+		m.status = ""
+		form, cmd := newWorkItemForm(msg)
+		m.dialog = form
+		return m, cmd
+
+	case workItemSubmittedMsg:
+		m.status = fmt.Sprintf("creating the %s…", msg.TypeName)
+		return m, workitems.CreateWorkItem(context.Background(), m.client, msg.TypeName, msg.Fields, msg.ParentID)
+
+	case workitems.WorkItemCreatedMsg:
+		m.status = fmt.Sprintf("created #%d", msg.Item.ID)
+		if m.tree != nil {
+			m.tree.addRoot(msg.Item)
+			cmd := m.list.SetItems(m.tree.rows())
+			m.list.Select(0)
+			return m, tea.Batch(cmd, m.refreshPreview())
+		}
+		if m.tabIndex == 0 {
+			cmd := m.list.InsertItem(0, msg.Item)
+			m.list.Select(0)
+			return m, tea.Batch(cmd, m.refreshPreview())
+		}
+		return m, nil
+
+	case workitems.HistoryMsg:
+		m.status = ""
+		m.dialog = newHistoryView(msg, max(40, m.width-12), max(5, m.height-16))
+		return m, nil
+
+	case workitems.RelationsMsg:
+		m.status = ""
+		if msg.ParentID != 0 {
+			return m, m.childrenLoaded(msg)
+		}
+		if m.tabIndex != 0 {
+			return m, nil
+		}
+		return m, tea.Batch(m.showTree(msg.Items), m.refreshPreview())
+
+	case workitems.LinksMsg:
+		m.status = ""
+		m.replaceItem(msg.Item)
+		if len(msg.Linked) == 0 {
+			m.status = fmt.Sprintf("#%d has no linked work items", msg.Item.ID)
+			return m, nil
+		}
+		m.dialog = newJumpPicker(msg)
+		return m, nil
+
+	case workitems.LinkManagerMsg:
+		m.status = ""
+		m.replaceItem(msg.Item)
+		client := m.client
+		m.dialog = newLinkManager(msg, func(text string) tea.Cmd {
+			return workitems.SearchWorkItems(context.Background(), client, text)
+		})
+		return m, nil
+
+	case linkChangedMsg:
+		return m, m.changeLink(msg)
+
+	case jumpMsg:
+		return m, m.jumpTo(workitemsmodels.WorkItem(msg))
+
+	case loadPreviewMsg:
+		return m, m.loadPreview(int(msg))
+
+	case workitems.PreviewLinksMsg:
+		m.linksLoaded(msg)
+		return m, nil
+
+	case workitems.CommentsMsg:
+		m.commentsLoaded(msg)
+		return m, nil
+
+	case commentSubmittedMsg:
+		m.status = fmt.Sprintf("posting a comment on #%d…", msg.Item.ID)
+		return m, workitems.PostComment(context.Background(), m.client, msg.Item.ID, msg.Text, msg.Mentions)
+
+	case workitems.CommentPostedMsg:
+		m.status = fmt.Sprintf("commented on #%d", msg.Comment.WorkItemID)
+		m.commentPosted(msg.Comment)
+		return m, m.refreshPreview()
 
 	case tea.KeyMsg:
+		if m.dialog != nil {
+			var cmd tea.Cmd
+			m.dialog, cmd = m.dialog.Update(msg)
+			return m, cmd
+		}
+
 		if m.list.FilterState() == list.Filtering {
 			break
 		}
 
+		if m.tabIndex == 2 {
+			if cmd, ok := m.updateBoard(msg); ok {
+				return m, cmd
+			}
+		}
+
 		switch msg.String() {
 		case "ctrl+y":
-			if i, ok := m.list.SelectedItem().(models.UiItem); ok {
+			if i, ok := m.current().(models.UiItem); ok {
 				if err := clipboard.WriteAll(fmt.Sprintf("%d", i.GetID())); err != nil {
-					log.Fatalf("Failed to copy to clipboard: %v", err)
+					m.err = models.ErrorMsg{Action: "could not copy to the clipboard", Err: err}
 				}
 			}
 		case "ctrl+c", "q":
+			m.cancelFetch()
 			return m, tea.Quit
 		case "enter":
-			if i, ok := m.list.SelectedItem().(models.UiItem); ok {
-				browser.OpenURL(i.GetURL())
+			if i, ok := m.current().(models.UiItem); ok {
+				if err := clipboard.WriteAll(i.GetURL()); err != nil {
+					m.err = models.ErrorMsg{Action: "could not copy to the clipboard", Err: err}
+				}
+
+				if err := browser.OpenURL(i.GetURL()); err != nil {
+					m.err = models.ErrorMsg{Action: "could not open the browser", Err: err}
+				}
 			}
+		case "r":
+			m.err = nil
+			cmds = append(cmds, m.refetch())
 		case "w":
 			m.tabIndex = 0
-			cmds = append(cmds, workitems.FetchWorkItems)
+			cmds = append(cmds, m.refetch())
 		case "p":
 			m.tabIndex = 1
-			cmds = append(cmds, pullrequests.FetchPullRequests)
+			cmds = append(cmds, m.refetch())
+		case "o":
+			m.dialog = newProfilePicker(m.config, m.profile)
+			return m, nil
+		case "v":
+			m.dialog = newViewPicker(m.profile, m.query)
+			return m, nil
+		case "e":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				editor, cmd := newFieldEditor(i)
+				m.dialog = editor
+				return m, cmd
+			}
+		case "E":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				cmds = append(cmds, editDescription(i))
+			}
+		case "n":
+			m.status = "loading work item types…"
+			cmds = append(cmds, workitems.FetchWorkItemTypes(m.fetchCtx, m.client))
+		case "m":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				composer, cmd := m.composeComment(i)
+				m.dialog = composer
+				return m, cmd
+			}
+		case "H":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				m.status = fmt.Sprintf("loading the history of #%d…", i.ID)
+				cmds = append(cmds, workitems.FetchHistory(m.fetchCtx, m.client, i))
+			}
+		case "T":
+			if m.tabIndex == 0 {
+				cmds = append(cmds, m.toggleTree())
+			}
+		case " ":
+			if m.tree != nil {
+				cmds = append(cmds, m.toggleNode())
+			}
+		case "J":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				m.status = fmt.Sprintf("loading the links of #%d…", i.ID)
+				cmds = append(cmds, workitems.FetchLinks(m.fetchCtx, m.client, i))
+			}
+		case "L":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				m.status = fmt.Sprintf("loading the links of #%d…", i.ID)
+				cmds = append(cmds, workitems.FetchLinkManager(m.fetchCtx, m.client, i))
+			}
+		case "S":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				m.status = fmt.Sprintf("loading the states of #%d…", i.ID)
+				cmds = append(cmds, workitems.FetchStateTransitions(m.fetchCtx, m.client, i))
+			}
+		case "Q":
+			client, ctx := m.client, m.fetchCtx
+			queryBrowser, cmd := newQueryBrowser(func(parentID string) tea.Cmd {
+				return workitems.FetchQueryFolder(ctx, client, parentID)
+			})
+			m.dialog = queryBrowser
+			return m, cmd
+		case "W":
+			client, ctx := m.client, m.fetchCtx
+			editor, cmd := newWiqlEditor(m.query.Wiql, func(wiql string) tea.Cmd {
+				return workitems.ValidateWiql(ctx, client, wiql)
+			})
+			m.dialog = editor
+			return m, cmd
+		case "a":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				if i.Fields.WorkItemType != "Task" {
+					m.status = fmt.Sprintf("time is logged on tasks, #%d is a %s", i.ID, i.Fields.WorkItemType)
+					break
+				}
+
+				m.status = fmt.Sprintf("loading the logged time of #%d…", i.ID)
+				cmds = append(cmds, workitems.FetchTimeLog(m.fetchCtx, m.client, i))
+			}
+		case "t":
+			if i, ok := m.current().(workitemsmodels.WorkItem); ok {
+				editor, cmd := newTagEditor(i)
+				m.dialog = editor
+				return m, tea.Batch(cmd, workitems.FetchTags(m.fetchCtx, m.client))
+			}
+		case "F":
+			m.dialog = newTagFilter(m.tagFilter)
+			return m, workitems.FetchTags(m.fetchCtx, m.client)
+		case "i":
+			m.status = "loading the iterations…"
+			cmds = append(cmds, iterations.FetchIterations(m.fetchCtx, m.client))
+		case "s":
+			m.tabIndex = 2
+			m.status = "loading the board…"
+			cmds = append(cmds, m.refetch())
+		case "c":
+			m.tabIndex = 3
+			m.status = "loading the burndown…"
+			cmds = append(cmds, m.refetch())
+		}
+
+		if m.tabIndex >= 2 {
+			return m, tea.Batch(cmds...)
 		}
 
+	case boards.BoardMsg:
+		m.err = nil
+		m.board = newBoardView(msg)
+		m.status = fmt.Sprintf("%s · %s · %d work items · </> move", msg.Board.Name, msg.Iteration.Name, len(msg.Items))
+		return m, nil
+
+	case iterations.BurndownMsg:
+		m.err = nil
+		m.burndown = newBurndownView(msg)
+		m.status = fmt.Sprintf("%s · %d work items", msg.Iteration.Name, len(msg.Items))
+		return m, nil
+
 	case workitems.WorkItemsResponseMsg:
-		return m, handleResponseMsg(&m, msg)
+		m.err, m.status = nil, ""
+		return m, handleResponseMsg(&m, msg.Items, msg.Pager, msg.Append, workitems.FetchMoreWorkItems)
 	case pullrequests.PullRequestResponseMsg:
-		return m, handleResponseMsg(&m, msg)
+		m.err, m.status = nil, ""
+		return m, handleResponseMsg(&m, msg.Items, msg.Pager, msg.Append, pullrequests.FetchMorePullRequests)
+	}
+
+	if m.dialog != nil {
+		var cmd tea.Cmd
+		m.dialog, cmd = m.dialog.Update(msg)
+		return m, cmd
 	}
 
 	newListModel, cmd := m.list.Update(msg)
 	m.list = newListModel
 	cmds = append(cmds, cmd)
 
-	if i, ok := m.list.SelectedItem().(models.UiItem); ok {
-		m.preview.SetContent(i.GetPreview(m.renderer))
-		m.selectedItem = i.GetID()
+	if m.loadMore != nil && !m.loadingMore && m.tree == nil && m.list.FilterState() == list.Unfiltered && m.list.Index() >= len(m.list.Items())-loadMoreThreshold {
+		m.loadingMore = true
+		m.status = "loading more…"
+		cmds = append(cmds, m.loadMore(m.fetchCtx))
 	}
 
+	cmds = append(cmds, m.refreshPreview())
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -190,6 +685,18 @@ func (m Model) View() string {
 	var tabViews []string
 
 	for i, text := range tabs {
+		if i == 0 {
+			text += " · " + m.query.Name
+		}
+
+		if i == 0 && !m.tagFilter.IsZero() {
+			text += " · " + m.tagFilter.String()
+		}
+
+		if i != 1 && m.iteration.ID != "" {
+			text += " · " + m.iteration.Name
+		}
+
 		if i == m.tabIndex {
 			tabViews = append(tabViews, activeTab.Render(text))
 		} else {
@@ -198,8 +705,9 @@ func (m Model) View() string {
 	}
 
 	tabView := lipgloss.JoinHorizontal(lipgloss.Top, tabViews...)
-	gap := tabGap.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(tabView)-8)))
-	tabView = lipgloss.JoinHorizontal(lipgloss.Bottom, tabView, gap)
+	profileView := tabGap.Foreground(subtle).Render(fmt.Sprintf("(O) %s · %s", m.profile.Name, m.profile.Project))
+	gap := tabGap.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(tabView)-lipgloss.Width(profileView)-8)))
+	tabView = lipgloss.JoinHorizontal(lipgloss.Bottom, tabView, gap, profileView)
 
 	listView := lipgloss.NewStyle().
 		MarginLeft(2).
@@ -213,13 +721,61 @@ func (m Model) View() string {
 		Render(m.preview.View())
 
 	body := lipgloss.JoinHorizontal(0, listView, previewView)
+	if m.tabIndex == 2 {
+		body = "  Loading the board…"
+		if m.board != nil {
+			body = lipgloss.NewStyle().MarginLeft(2).Render(m.board.View(m.width-4, m.height-5))
+		}
+	}
+	if m.tabIndex == 3 {
+		body = "  Loading the burndown…"
+		if m.burndown != nil {
+			body = lipgloss.NewStyle().MarginLeft(2).Render(m.burndown.View(m.width-4, m.height-5))
+		}
+	}
+	if m.dialog != nil {
+		body = renderDialog(m.dialog, m.width, m.height-5)
+	}
 	header := lipgloss.NewStyle().Padding(0, 3).Render(tabView)
 
-	return lipgloss.JoinVertical(0, header, body)
+	return lipgloss.JoinVertical(0, header, m.statusView(), body)
 }
 
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+	configPath := flag.String("config", config.DefaultPath(), "path to the config file")
+	organization := flag.String("org", "", "organization url, e.g. https://dev.azure.com/my-org")
+	project := flag.String("project", "", "default project")
+	team := flag.String("team", "", "team whose iterations and board are shown")
+	repositories := flag.String("repos", "", "comma separated list of repositories")
+	apiVersion := flag.String("api-version", "", "Azure DevOps REST api-version")
+	wiql := flag.String("wiql", "", "WIQL query used by the Work Items tab")
+	profileName := flag.String("profile", "", "name of the profile to start with")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg = cfg.Override(config.Profile{
+		Organization: *organization,
+		Project:      *project,
+		Team:         *team,
+		Repositories: config.SplitList(*repositories),
+		ApiVersion:   *apiVersion,
+		Wiql:         *wiql,
+	})
+
+	profile, err := cfg.ActiveProfile(*profileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := profile.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	p := tea.NewProgram(initialModel(cfg, *configPath, profile), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v", err)
 	}
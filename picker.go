@@ -0,0 +1,63 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type PickerItem struct {
+	Name    string
+	Details string
+	Value   any
+}
+
+func (i PickerItem) Title() string       { return i.Name }
+func (i PickerItem) Description() string { return i.Details }
+func (i PickerItem) FilterValue() string { return i.Name }
+
+// Picker is a filterable list dialog. Choosing an item closes the dialog and
+// sends the message built by onSelect.
+type Picker struct {
+	list     list.Model
+	onSelect func(PickerItem) tea.Msg
+}
+
+func NewPicker(title string, items []PickerItem, selected int, onSelect func(PickerItem) tea.Msg) Picker {
+	var listItems []list.Item
+	for _, item := range items {
+		listItems = append(listItems, item)
+	}
+
+	l := list.New(listItems, list.NewDefaultDelegate(), 60, min(20, len(items)*3+6))
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.Select(selected)
+
+	return Picker{list: l, onSelect: onSelect}
+}
+
+func (p Picker) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && p.list.FilterState() != list.Filtering {
+		switch msg.String() {
+		case "esc", "q":
+			return p, closeDialog
+		case "enter":
+			item, ok := p.list.SelectedItem().(PickerItem)
+			if !ok {
+				return p, closeDialog
+			}
+
+			return p, tea.Sequence(closeDialog, func() tea.Msg { return p.onSelect(item) })
+		}
+	}
+
+	var cmd tea.Cmd
+	p.list, cmd = p.list.Update(msg)
+
+	return p, cmd
+}
+
+func (p Picker) View() string {
+	return p.list.View()
+}
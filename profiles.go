@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"lazyaz/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type profileSelectedMsg config.Profile
+
+// patResolvedMsg carries the PAT of Profile, or the error resolving it.
+type patResolvedMsg struct {
+	Profile config.Profile
+	Pat     string
+	Err     error
+}
+
+// resolvePat reads the PAT of profile outside of the UI loop, its pat_command
+// possibly taking a while, e.g. when it unlocks a password manager.
+func resolvePat(profile config.Profile) tea.Cmd {
+	return func() tea.Msg {
+		pat, err := profile.Pat()
+		return patResolvedMsg{Profile: profile, Pat: pat, Err: err}
+	}
+}
+
+func newProfilePicker(cfg config.Config, active config.Profile) Picker {
+	var items []PickerItem
+	selected := 0
+
+	for i, profile := range cfg.AllProfiles() {
+		if profile.Name == active.Name {
+			selected = i
+		}
+
+		items = append(items, PickerItem{
+			Name:    profile.Name,
+			Details: fmt.Sprintf("%s · %s", profile.Organization, profile.Project),
+			Value:   profile,
+		})
+	}
+
+	return NewPicker("Profiles", items, selected, func(item PickerItem) tea.Msg {
+		return profileSelectedMsg(item.Value.(config.Profile))
+	})
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"lazyaz/internal/config"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type queryRow struct {
+	item     workitemsmodels.QueryItem
+	depth    int
+	expanded bool
+	loading  bool
+}
+
+func (r queryRow) Title() string {
+	icon := "  "
+	switch {
+	case r.loading:
+		icon = "… "
+	case r.item.IsFolder && r.expanded:
+		icon = "▾ "
+	case r.item.IsFolder:
+		icon = "▸ "
+	}
+
+	return strings.Repeat("  ", r.depth) + icon + r.item.Name
+}
+
+func (r queryRow) Description() string {
+	if r.item.IsFolder {
+		return strings.Repeat("  ", r.depth) + "  folder"
+	}
+
+	return strings.Repeat("  ", r.depth) + "  " + r.item.QueryType + " query"
+}
+
+func (r queryRow) FilterValue() string { return r.item.Path }
+
+// QueryBrowser shows the "My Queries" and "Shared Queries" hierarchy of the
+// project, loading folders as they are expanded. Choosing a query opens it as
+// the current Work Items view.
+type QueryBrowser struct {
+	list     list.Model
+	roots    []workitemsmodels.QueryItem
+	children map[string][]workitemsmodels.QueryItem
+	expanded map[string]bool
+	loading  map[string]bool
+	fetch    func(parentID string) tea.Cmd
+	err      error
+}
+
+func newQueryBrowser(fetch func(parentID string) tea.Cmd) (QueryBrowser, tea.Cmd) {
+	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 70, 20)
+	l.Title = "Queries"
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+
+	b := QueryBrowser{
+		list:     l,
+		children: make(map[string][]workitemsmodels.QueryItem),
+		expanded: make(map[string]bool),
+		loading:  map[string]bool{"": true},
+		fetch:    fetch,
+	}
+
+	return b, tea.Batch(b.list.StartSpinner(), fetch(""))
+}
+
+func (b QueryBrowser) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workitems.QueryFolderMsg:
+		delete(b.loading, msg.ParentID)
+		b.err = msg.Err
+		switch {
+		case msg.Err != nil:
+			// The folder is collapsed so expanding it again retries.
+			delete(b.expanded, msg.ParentID)
+			b.list.StopSpinner()
+		case msg.ParentID == "":
+			b.roots = msg.Items
+			b.list.StopSpinner()
+		default:
+			b.children[msg.ParentID] = msg.Items
+		}
+
+		return b, b.refresh()
+
+	case tea.KeyMsg:
+		if b.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return b, closeDialog
+		case "enter", " ":
+			row, ok := b.list.SelectedItem().(queryRow)
+			if !ok {
+				return b, nil
+			}
+
+			if !row.item.IsFolder {
+				query := config.Query{Name: row.item.Name, ID: row.item.ID}
+				return b, tea.Sequence(closeDialog, func() tea.Msg { return querySelectedMsg(query) })
+			}
+
+			return b, b.toggle(row.item)
+		}
+	}
+
+	var cmd tea.Cmd
+	b.list, cmd = b.list.Update(msg)
+
+	return b, cmd
+}
+
+func (b *QueryBrowser) toggle(folder workitemsmodels.QueryItem) tea.Cmd {
+	if b.expanded[folder.ID] {
+		delete(b.expanded, folder.ID)
+		return b.refresh()
+	}
+
+	b.expanded[folder.ID] = true
+	if _, loaded := b.children[folder.ID]; loaded {
+		return b.refresh()
+	}
+
+	if folder.ChildrenLoaded() {
+		b.children[folder.ID] = folder.Children
+		return b.refresh()
+	}
+
+	b.loading[folder.ID] = true
+
+	return tea.Batch(b.refresh(), b.fetch(folder.ID))
+}
+
+func (b *QueryBrowser) refresh() tea.Cmd {
+	var rows []list.Item
+	b.appendRows(&rows, b.roots, 0)
+
+	return b.list.SetItems(rows)
+}
+
+func (b *QueryBrowser) appendRows(rows *[]list.Item, items []workitemsmodels.QueryItem, depth int) {
+	for _, item := range items {
+		expanded := b.expanded[item.ID]
+		*rows = append(*rows, queryRow{item: item, depth: depth, expanded: expanded, loading: b.loading[item.ID]})
+
+		if expanded && !b.loading[item.ID] {
+			b.appendRows(rows, b.children[item.ID], depth+1)
+		}
+	}
+}
+
+func (b QueryBrowser) View() string {
+	switch {
+	case b.err != nil && len(b.roots) == 0:
+		return fmt.Sprintf("%s\n\n%s", b.list.Title, errorStyle.UnsetPadding().Width(70).Render(describeError(b.err)))
+	case len(b.loading) > 0 && len(b.roots) == 0:
+		return fmt.Sprintf("%s\n\nLoading queries…", b.list.Title)
+	case b.err != nil:
+		return lipgloss.JoinVertical(lipgloss.Left, b.list.View(), errorStyle.UnsetPadding().Width(70).Render(describeError(b.err)))
+	}
+
+	return b.list.View()
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stateChosenMsg asks to move Item to State.
+type stateChosenMsg struct {
+	Item  workitemsmodels.WorkItem
+	State string
+}
+
+func newStatePicker(msg workitems.StateTransitionsMsg) Picker {
+	var items []PickerItem
+	for _, state := range msg.NextStates {
+		items = append(items, PickerItem{Name: state.Name, Details: state.Category, Value: state.Name})
+	}
+
+	title := fmt.Sprintf("Move #%d from %s to", msg.Item.ID, msg.Item.Fields.State)
+
+	return NewPicker(title, items, 0, func(item PickerItem) tea.Msg {
+		return stateChosenMsg{Item: msg.Item, State: item.Value.(string)}
+	})
+}
+
+// changeState shows the new state right away and sends the update, which is
+// reverted if the server rejects it.
+func (m *Model) changeState(msg stateChosenMsg) tea.Cmd {
+	updated := msg.Item
+	updated.Fields.State = msg.State
+	m.replaceItem(updated)
+	m.status = fmt.Sprintf("moving #%d to %s…", msg.Item.ID, msg.State)
+
+	return workitems.UpdateWorkItem(context.Background(), m.client, msg.Item, workitems.ChangeState(msg.State))
+}
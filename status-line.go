@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	azhttpclient "lazyaz/internal/http"
+	"lazyaz/internal/models"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	errorColor = lipgloss.AdaptiveColor{Light: "#D7263D", Dark: "#FF5F87"}
+
+	statusStyle = lipgloss.NewStyle().Padding(0, 3).Foreground(subtle)
+	errorStyle  = statusStyle.Foreground(errorColor).Bold(true)
+)
+
+// statusView renders the single line between the tabs and the panes, which
+// shows the last error together with the retry hint, or the current status.
+func (m Model) statusView() string {
+	width := max(0, m.width)
+
+	if m.err != nil {
+		return errorStyle.Width(width).MaxHeight(1).Render("✗ " + describeError(m.err) + "  (r) retry")
+	}
+
+	return statusStyle.Width(width).MaxHeight(1).Render(m.status)
+}
+
+type retryMsg azhttpclient.RetryEvent
+
+// waitForRetry delivers the next retry announced by the client as a retryMsg.
+func waitForRetry(retries chan azhttpclient.RetryEvent) tea.Cmd {
+	return func() tea.Msg {
+		return retryMsg(<-retries)
+	}
+}
+
+func retryStatus(event azhttpclient.RetryEvent) string {
+	wait := max(time.Second, event.Wait.Round(time.Second))
+
+	if event.Throttled() {
+		return fmt.Sprintf("⏳ throttled, retrying in %s", wait)
+	}
+
+	return fmt.Sprintf("⏳ request failed, retrying in %s (attempt %d)", wait, event.Attempt+1)
+}
+
+// describeError turns the Azure DevOps errors users can act on into a short
+// explanation instead of the raw response body.
+func describeError(err error) string {
+	var prefix string
+
+	var errorMsg models.ErrorMsg
+	if errors.As(err, &errorMsg) {
+		prefix = errorMsg.Action + ": "
+	}
+
+	azErr, ok := azhttpclient.AsAzError(err)
+	switch {
+	case !ok:
+		return err.Error()
+	case azhttpclient.IsUnauthorized(err):
+		return prefix + "PAT expired, revoked or missing the required scopes"
+	case azhttpclient.IsThrottled(err):
+		return prefix + "throttled by Azure DevOps, try again in a moment"
+	case azhttpclient.IsNotFound(err):
+		return prefix + "not found: " + azErr.Message
+	case azhttpclient.IsQueryError(err):
+		return prefix + "query syntax error: " + azErr.Message
+	default:
+		return prefix + azErr.Message
+	}
+}
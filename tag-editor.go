@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var selectedTagStyle = lipgloss.NewStyle().Underline(true).Bold(true)
+
+// TagEditor adds tags to a work item, completed from the tags of the
+// project, and removes them. The tags are saved together as System.Tags.
+type TagEditor struct {
+	item        workitemsmodels.WorkItem
+	tags        []string
+	selected    int
+	input       textinput.Model
+	projectTags []string
+	err         error
+}
+
+func newTagEditor(item workitemsmodels.WorkItem) (TagEditor, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "Tag: "
+	input.Placeholder = "loading the project tags…"
+	input.Width = 40
+	input.ShowSuggestions = true
+	input.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	input.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	input.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+
+	e := TagEditor{item: item, tags: item.TagList(), selected: -1, input: input}
+
+	return e, e.input.Focus()
+}
+
+func (e TagEditor) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workitems.TagsMsg:
+		e.projectTags = msg.Tags
+		e.err = msg.Err
+		e.input.Placeholder = "new or existing tag"
+		e.suggest()
+		return e, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return e, closeDialog
+		case "ctrl+s":
+			return e, e.save()
+		case "enter":
+			tag := strings.TrimSpace(strings.ReplaceAll(e.input.Value(), ";", ""))
+			if tag == "" {
+				return e, e.save()
+			}
+
+			if !containsFold(e.tags, tag) {
+				e.tags = append(e.tags, tag)
+			}
+			e.input.SetValue("")
+			e.suggest()
+			return e, nil
+		case "tab":
+			if len(e.tags) > 0 {
+				e.selected = (e.selected + 1) % len(e.tags)
+			}
+			return e, nil
+		case "shift+tab":
+			if len(e.tags) > 0 {
+				e.selected = (max(0, e.selected) - 1 + len(e.tags)) % len(e.tags)
+			}
+			return e, nil
+		case "delete", "ctrl+d":
+			e.remove()
+			return e, nil
+		case "backspace":
+			if e.input.Value() == "" {
+				if e.selected < 0 {
+					e.selected = len(e.tags) - 1
+				} else {
+					e.remove()
+				}
+				return e, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	e.input, cmd = e.input.Update(msg)
+
+	return e, cmd
+}
+
+// remove drops the selected tag.
+func (e *TagEditor) remove() {
+	if e.selected < 0 || e.selected >= len(e.tags) {
+		return
+	}
+
+	e.tags = slices.Delete(e.tags, e.selected, e.selected+1)
+	e.selected = -1
+	e.suggest()
+}
+
+// suggest offers the project tags the work item does not have yet.
+func (e *TagEditor) suggest() {
+	var suggestions []string
+	for _, tag := range e.projectTags {
+		if !containsFold(e.tags, tag) {
+			suggestions = append(suggestions, tag)
+		}
+	}
+
+	e.input.SetSuggestions(suggestions)
+}
+
+// save closes the editor, sending the tags when they changed.
+func (e TagEditor) save() tea.Cmd {
+	tags := workitemsmodels.JoinTags(e.tags)
+	if tags == workitemsmodels.JoinTags(e.item.TagList()) {
+		return closeDialog
+	}
+
+	edited := fieldsEditedMsg{Item: e.item, Edits: []workitems.FieldEdit{{ReferenceName: "System.Tags", Value: tags}}}
+
+	return tea.Sequence(closeDialog, func() tea.Msg { return edited })
+}
+
+func (e TagEditor) View() string {
+	var chips []string
+	for i, tag := range e.tags {
+		chip := tagChip(tag)
+		if i == e.selected {
+			chip = selectedTagStyle.Render("✗") + chip
+		}
+		chips = append(chips, chip)
+	}
+
+	tags := helpStyle.Render("No tags")
+	if len(chips) > 0 {
+		tags = lipgloss.NewStyle().Width(60).Render(strings.Join(chips, " "))
+	}
+
+	lines := []string{
+		fmt.Sprintf("Tags of #%d %s", e.item.ID, e.item.Fields.Title),
+		"",
+		tags,
+		"",
+		e.input.View(),
+		"",
+	}
+
+	if e.err != nil {
+		lines = append(lines, errorStyle.UnsetPadding().Width(60).Render(describeError(e.err)))
+	}
+
+	lines = append(lines, helpStyle.Render("enter add · right accept suggestion · tab/shift+tab select · del remove · enter on empty or ctrl+s save · esc cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
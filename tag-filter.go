@@ -0,0 +1,181 @@
+package main
+
+import (
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type tagFilterChosenMsg workitemsmodels.TagFilter
+
+type tagMark int
+
+const (
+	tagIgnored tagMark = iota
+	tagIncluded
+	tagExcluded
+)
+
+type tagFilterItem struct {
+	tag  string
+	mark tagMark
+}
+
+func (i tagFilterItem) Title() string {
+	switch i.mark {
+	case tagIncluded:
+		return "[+] " + i.tag
+	case tagExcluded:
+		return "[-] " + i.tag
+	}
+
+	return "[ ] " + i.tag
+}
+
+func (i tagFilterItem) Description() string { return "" }
+func (i tagFilterItem) FilterValue() string { return i.tag }
+
+// TagFilterPicker picks the tags the Work Items tab requires, all or any of
+// them, and the ones it excludes.
+type TagFilterPicker struct {
+	filter  workitemsmodels.TagFilter
+	list    list.Model
+	loading bool
+	err     error
+}
+
+func newTagFilter(filter workitemsmodels.TagFilter) TagFilterPicker {
+	delegate := list.NewDefaultDelegate()
+	delegate.ShowDescription = false
+	delegate.SetSpacing(0)
+
+	l := list.New(nil, delegate, 50, 18)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+
+	f := TagFilterPicker{filter: filter, list: l, loading: true}
+	f.setTitle()
+	f.setTags(nil)
+
+	return f
+}
+
+func (f *TagFilterPicker) setTitle() {
+	f.list.Title = "Work items with all the tags marked +"
+	if f.filter.Any {
+		f.list.Title = "Work items with any tag marked +"
+	}
+}
+
+func (f TagFilterPicker) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workitems.TagsMsg:
+		f.loading = false
+		f.err = msg.Err
+		return f, f.setTags(msg.Tags)
+
+	case tea.KeyMsg:
+		if f.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return f, closeDialog
+		case " ":
+			if item, ok := f.list.SelectedItem().(tagFilterItem); ok {
+				item.mark = (item.mark + 1) % 3
+				return f, f.list.SetItem(f.list.Index(), item)
+			}
+			return f, nil
+		case "a":
+			f.filter.Any = !f.filter.Any
+			f.setTitle()
+			return f, nil
+		case "c":
+			var cmds []tea.Cmd
+			for index, item := range f.list.Items() {
+				if item, ok := item.(tagFilterItem); ok && item.mark != tagIgnored {
+					item.mark = tagIgnored
+					cmds = append(cmds, f.list.SetItem(index, item))
+				}
+			}
+			return f, tea.Batch(cmds...)
+		case "enter":
+			chosen := f.chosen()
+			return f, tea.Sequence(closeDialog, func() tea.Msg { return tagFilterChosenMsg(chosen) })
+		}
+	}
+
+	var cmd tea.Cmd
+	f.list, cmd = f.list.Update(msg)
+
+	return f, cmd
+}
+
+// setTags lists tags along with the tags of the filter, so applying it keeps
+// them while the project tags are loading or when they failed to load.
+func (f *TagFilterPicker) setTags(tags []string) tea.Cmd {
+	tags = slices.Clone(tags)
+	for _, tag := range slices.Concat(f.filter.Include, f.filter.Exclude) {
+		if !containsFold(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	var items []list.Item
+	for _, tag := range tags {
+		item := tagFilterItem{tag: tag}
+		switch {
+		case containsFold(f.filter.Include, tag):
+			item.mark = tagIncluded
+		case containsFold(f.filter.Exclude, tag):
+			item.mark = tagExcluded
+		}
+		items = append(items, item)
+	}
+
+	return f.list.SetItems(items)
+}
+
+// chosen reads the filter from the marks of the tags.
+func (f TagFilterPicker) chosen() workitemsmodels.TagFilter {
+	chosen := workitemsmodels.TagFilter{Any: f.filter.Any}
+	for _, item := range f.list.Items() {
+		item, ok := item.(tagFilterItem)
+		switch {
+		case !ok:
+		case item.mark == tagIncluded:
+			chosen.Include = append(chosen.Include, item.tag)
+		case item.mark == tagExcluded:
+			chosen.Exclude = append(chosen.Exclude, item.tag)
+		}
+	}
+
+	return chosen
+}
+
+func (f TagFilterPicker) View() string {
+	body := f.list.View()
+	if f.loading {
+		body = lipgloss.JoinVertical(lipgloss.Left, f.list.Title, "", "Loading the project tags…")
+	}
+
+	lines := []string{body}
+	if f.err != nil {
+		lines = append(lines, errorStyle.UnsetPadding().Width(50).Render(describeError(f.err)))
+	}
+
+	lines = append(lines, helpStyle.Render("space cycle +/-/none · a all/any · c clear · / search · enter apply · esc cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
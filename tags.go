@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tagColors are the chip backgrounds, a tag always getting the same one.
+var tagColors = []lipgloss.AdaptiveColor{
+	{Light: "#F7C6C7", Dark: "#8E3B46"},
+	{Light: "#FAD8A6", Dark: "#8A5A1C"},
+	{Light: "#FDF1A8", Dark: "#7A6A12"},
+	{Light: "#C8E6C9", Dark: "#2E6B3A"},
+	{Light: "#B3E5FC", Dark: "#1F5F80"},
+	{Light: "#D1C4E9", Dark: "#553C8B"},
+	{Light: "#F8BBD0", Dark: "#8A2F5B"},
+	{Light: "#CFD8DC", Dark: "#4A5A63"},
+}
+
+var tagChipStyle = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.AdaptiveColor{Light: "#1A1A1A", Dark: "#F5F5F5"})
+
+func tagChip(tag string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.ToLower(tag)))
+
+	return tagChipStyle.Background(tagColors[hash.Sum32()%uint32(len(tagColors))]).Render(tag)
+}
+
+// tagChips renders tags as chips fitting in width, the ones left out counted
+// at the end.
+func tagChips(tags []string, width int) string {
+	var chips []string
+	used := 0
+
+	for i, tag := range tags {
+		chip := tagChip(tag)
+
+		// Room is kept for the count of the tags after this one.
+		reserved := 0
+		if i < len(tags)-1 {
+			reserved = len(fmt.Sprintf(" +%d", len(tags)-1-i))
+		}
+
+		if used+lipgloss.Width(chip)+reserved > width {
+			if left := fmt.Sprintf("+%d", len(tags)-i); used+len(left) <= width {
+				chips = append(chips, helpStyle.Render(left))
+			}
+			break
+		}
+
+		chips = append(chips, chip)
+		used += lipgloss.Width(chip) + 1
+	}
+
+	return strings.Join(chips, " ")
+}
+
+type taggedItem interface {
+	TagList() []string
+}
+
+// workItemDelegate is the default delegate, drawing the tags of work items
+// as chips after their description.
+type workItemDelegate struct {
+	list.DefaultDelegate
+}
+
+func newWorkItemDelegate() workItemDelegate {
+	return workItemDelegate{DefaultDelegate: list.NewDefaultDelegate()}
+}
+
+func (d workItemDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	tagged, ok := item.(taggedItem)
+	if !ok || !d.ShowDescription || len(tagged.TagList()) == 0 {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	var rendered strings.Builder
+	d.DefaultDelegate.Render(&rendered, m, index, item)
+
+	lines := strings.Split(rendered.String(), "\n")
+	description := len(lines) - 1
+	if chips := tagChips(tagged.TagList(), m.Width()-lipgloss.Width(lines[description])-1); chips != "" {
+		lines[description] += " " + chips
+	}
+
+	io.WriteString(w, strings.Join(lines, "\n"))
+}
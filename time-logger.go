@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timeLoggedMsg asks to log Hours on Item.
+type timeLoggedMsg struct {
+	Item  workitemsmodels.WorkItem
+	Hours float64
+}
+
+// logTime shows the hours moved to the completed work right away and sends
+// the update, which is reverted if the server rejects it.
+func (m *Model) logTime(msg timeLoggedMsg) tea.Cmd {
+	m.replaceItem(msg.Item.LogTime(msg.Hours))
+	m.status = fmt.Sprintf("logging %s on #%d…", formatHours(msg.Hours), msg.Item.ID)
+
+	return workitems.LogTime(context.Background(), m.client, msg.Item, msg.Hours)
+}
+
+// TimeLogger logs the hours spent on a Task, and shows the time logged on it
+// each day so far.
+type TimeLogger struct {
+	log   workitems.TimeLogMsg
+	input textinput.Model
+	err   error
+}
+
+func newTimeLogger(msg workitems.TimeLogMsg) (TimeLogger, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "Hours: "
+	input.Placeholder = "1.5"
+	input.CharLimit = 6
+
+	return TimeLogger{log: msg, input: input}, input.Focus()
+}
+
+func (d TimeLogger) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			return d, closeDialog
+		case "enter":
+			hours, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(d.input.Value()), ",", ".", 1), 64)
+			if err != nil || hours <= 0 {
+				d.err = fmt.Errorf("the hours must be a positive number")
+				return d, nil
+			}
+
+			logged := timeLoggedMsg{Item: d.log.Item, Hours: hours}
+			return d, tea.Sequence(closeDialog, func() tea.Msg { return logged })
+		}
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+
+	return d, cmd
+}
+
+func (d TimeLogger) View() string {
+	item := d.log.Item
+
+	lines := []string{
+		fmt.Sprintf("Log time on #%d %s", item.ID, item.Fields.Title),
+		helpStyle.Render(fmt.Sprintf("%s remaining · %s completed", formatHours(item.Fields.RemainingWork), formatHours(item.Fields.CompletedWork))),
+		"",
+	}
+
+	if len(d.log.Entries) == 0 {
+		lines = append(lines, helpStyle.Render("No time logged yet"))
+	} else {
+		lines = append(lines, "Logged per day")
+		for _, entry := range d.log.Entries {
+			lines = append(lines, fmt.Sprintf("  %s  %6s  %s",
+				entry.Day.Format("Mon Jan 2"), formatHours(entry.Hours), helpStyle.Render(strings.Join(entry.People, ", "))))
+		}
+	}
+
+	lines = append(lines, "", d.input.View())
+
+	if d.err != nil {
+		lines = append(lines, errorStyle.UnsetPadding().Render(d.err.Error()))
+	}
+
+	lines = append(lines, helpStyle.Render("enter log · esc cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
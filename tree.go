@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// workItemTree arranges work items by their parent/child links. The listed
+// work items whose parent is not listed are the roots; children outside the
+// list are loaded when their parent is expanded.
+type workItemTree struct {
+	items    map[int]workitemsmodels.WorkItem
+	roots    []int
+	expanded map[int]bool
+	loading  map[int]bool
+	depth    map[int]int
+}
+
+func newWorkItemTree(listed []workitemsmodels.WorkItem) *workItemTree {
+	t := &workItemTree{
+		items:    make(map[int]workitemsmodels.WorkItem),
+		expanded: make(map[int]bool),
+		loading:  make(map[int]bool),
+		depth:    make(map[int]int),
+	}
+
+	for _, item := range listed {
+		t.items[item.ID] = item
+	}
+
+	for _, item := range listed {
+		parentID, ok := item.ParentID()
+		if ok && t.items[parentID].ID != 0 {
+			t.expanded[parentID] = true
+			continue
+		}
+
+		t.roots = append(t.roots, item.ID)
+	}
+
+	return t
+}
+
+// rows flattens the expanded part of the tree, setting the depth of every row.
+func (t *workItemTree) rows() []list.Item {
+	var rows []list.Item
+	seen := make(map[int]bool)
+
+	var walk func(id, depth int)
+	walk = func(id, depth int) {
+		item, ok := t.items[id]
+		if !ok || seen[id] {
+			return
+		}
+
+		seen[id] = true
+		t.depth[id] = depth
+		rows = append(rows, item)
+
+		if t.expanded[id] {
+			for _, childID := range item.ChildIDs() {
+				walk(childID, depth+1)
+			}
+		}
+	}
+
+	for _, id := range t.roots {
+		walk(id, 0)
+	}
+
+	return rows
+}
+
+// missingChildren returns the children of id that are not loaded yet.
+func (t *workItemTree) missingChildren(id int) []int {
+	var missing []int
+	for _, childID := range t.items[id].ChildIDs() {
+		if _, ok := t.items[childID]; !ok {
+			missing = append(missing, childID)
+		}
+	}
+
+	return missing
+}
+
+func (t *workItemTree) add(items []workitemsmodels.WorkItem) {
+	for _, item := range items {
+		t.items[item.ID] = item
+	}
+}
+
+// replace updates a work item of the tree, keeping its relations when item
+// was loaded without them.
+func (t *workItemTree) replace(item workitemsmodels.WorkItem) {
+	current, ok := t.items[item.ID]
+	if !ok {
+		return
+	}
+
+	if item.Relations == nil {
+		item.Relations = current.Relations
+	}
+	t.items[item.ID] = item
+}
+
+func (t *workItemTree) addRoot(item workitemsmodels.WorkItem) {
+	t.items[item.ID] = item
+	t.roots = slices.Insert(t.roots, 0, item.ID)
+}
+
+// treeRow is a work item as drawn in the tree, indented under its parent.
+type treeRow struct {
+	workitemsmodels.WorkItem
+	prefix string
+}
+
+func (r treeRow) Title() string { return r.prefix + r.WorkItem.Title() }
+func (r treeRow) Description() string {
+	return strings.Repeat(" ", len([]rune(r.prefix))) + r.WorkItem.Description()
+}
+
+// treeDelegate draws the rows of the list as the tree t.
+type treeDelegate struct {
+	workItemDelegate
+	tree *workItemTree
+}
+
+func newTreeDelegate(tree *workItemTree) treeDelegate {
+	return treeDelegate{workItemDelegate: newWorkItemDelegate(), tree: tree}
+}
+
+func (d treeDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	workItem, ok := item.(workitemsmodels.WorkItem)
+	if !ok {
+		d.workItemDelegate.Render(w, m, index, item)
+		return
+	}
+
+	icon := "  "
+	switch {
+	case d.tree.loading[workItem.ID]:
+		icon = "… "
+	case len(workItem.ChildIDs()) == 0:
+	case d.tree.expanded[workItem.ID]:
+		icon = "▾ "
+	default:
+		icon = "▸ "
+	}
+
+	prefix := strings.Repeat("  ", d.tree.depth[workItem.ID]) + icon
+	d.workItemDelegate.Render(w, m, index, treeRow{WorkItem: workItem, prefix: prefix})
+}
+
+// toggleTree switches the Work Items list between the flat query results and
+// the tree of their links, loading the relations of the listed items first.
+func (m *Model) toggleTree() tea.Cmd {
+	if m.tree != nil {
+		var items []list.Item
+		for _, item := range m.flatItems {
+			if workItem, ok := item.(workitemsmodels.WorkItem); ok {
+				if loaded, ok := m.tree.items[workItem.ID]; ok {
+					item = loaded
+				}
+			}
+			items = append(items, item)
+		}
+
+		m.closeTree()
+		return m.list.SetItems(items)
+	}
+
+	var ids []int
+	for _, item := range m.list.Items() {
+		if item, ok := item.(workitemsmodels.WorkItem); ok {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	m.status = fmt.Sprintf("loading the links of %d work items…", len(ids))
+	return workitems.FetchRelations(m.fetchCtx, m.client, 0, ids)
+}
+
+func (m *Model) closeTree() {
+	m.tree, m.flatItems = nil, nil
+	m.list.SetDelegate(newWorkItemDelegate())
+}
+
+// showTree displays the tree built from the listed items loaded with their relations.
+func (m *Model) showTree(items []workitemsmodels.WorkItem) tea.Cmd {
+	m.flatItems = m.list.Items()
+	m.tree = newWorkItemTree(items)
+	m.list.ResetFilter()
+	m.list.SetDelegate(newTreeDelegate(m.tree))
+	m.status = "tree of the loaded work items · space expand · T flat list"
+
+	return m.list.SetItems(m.tree.rows())
+}
+
+// toggleNode expands or collapses the selected work item, loading the
+// children it does not have yet.
+func (m *Model) toggleNode() tea.Cmd {
+	item, ok := m.list.SelectedItem().(workitemsmodels.WorkItem)
+	if !ok || len(item.ChildIDs()) == 0 || m.tree.loading[item.ID] {
+		return nil
+	}
+
+	if m.tree.expanded[item.ID] {
+		delete(m.tree.expanded, item.ID)
+		return m.list.SetItems(m.tree.rows())
+	}
+
+	if missing := m.tree.missingChildren(item.ID); len(missing) > 0 {
+		m.tree.loading[item.ID] = true
+		return workitems.FetchRelations(m.fetchCtx, m.client, item.ID, missing)
+	}
+
+	m.tree.expanded[item.ID] = true
+	return m.list.SetItems(m.tree.rows())
+}
+
+// childrenLoaded adds the children of parentID to the tree and expands it.
+func (m *Model) childrenLoaded(msg workitems.RelationsMsg) tea.Cmd {
+	if m.tree == nil {
+		return nil
+	}
+
+	m.tree.add(msg.Items)
+	delete(m.tree.loading, msg.ParentID)
+	m.tree.expanded[msg.ParentID] = true
+
+	return m.list.SetItems(m.tree.rows())
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"lazyaz/internal/config"
+	"lazyaz/internal/models"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type querySelectedMsg config.Query
+
+func newViewPicker(profile config.Profile, active config.Query) Picker {
+	var items []PickerItem
+	selected := 0
+
+	for i, query := range profile.Views() {
+		if query == active {
+			selected = i
+		}
+
+		details := strings.Join(strings.Fields(query.Wiql), " ")
+		if query.ID != "" {
+			details = "saved query " + query.ID
+		}
+
+		items = append(items, PickerItem{
+			Name:    query.Name,
+			Details: details,
+			Value:   query,
+		})
+	}
+
+	return NewPicker("Work item views", items, selected, func(item PickerItem) tea.Msg {
+		return querySelectedMsg(item.Value.(config.Query))
+	})
+}
+
+// saveView appends query to the views of profileName in the config file.
+func saveView(configPath string, profileName string, query config.Query) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SaveQuery(configPath, profileName, query); err != nil {
+			return models.ErrorMsg{Action: "could not save the view", Err: err}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"lazyaz/internal/config"
+	"lazyaz/internal/models"
+	workitems "lazyaz/internal/work-items"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const adHocQueryName = "Ad-hoc WIQL"
+
+var helpStyle = lipgloss.NewStyle().Foreground(subtle)
+
+type viewSavedMsg config.Query
+
+// WiqlEditor lets the user write a WIQL query, validates it against the server
+// before running it and keeps a history of the queries that were run.
+type WiqlEditor struct {
+	editor       textarea.Model
+	name         textinput.Model
+	naming       bool
+	validating   bool
+	err          error
+	history      []string
+	historyIndex int
+	validate     func(wiql string) tea.Cmd
+}
+
+func newWiqlEditor(wiql string, validate func(wiql string) tea.Cmd) (WiqlEditor, tea.Cmd) {
+	editor := textarea.New()
+	editor.SetWidth(80)
+	editor.SetHeight(10)
+	editor.ShowLineNumbers = false
+	editor.Placeholder = "SELECT [System.Id] FROM WorkItems WHERE ..."
+	editor.SetValue(wiql)
+
+	name := textinput.New()
+	name.Placeholder = "View name"
+	name.CharLimit = 60
+
+	history, err := config.LoadHistory()
+
+	e := WiqlEditor{
+		editor:       editor,
+		name:         name,
+		err:          err,
+		history:      history,
+		historyIndex: -1,
+		validate:     validate,
+	}
+
+	return e, e.editor.Focus()
+}
+
+func (e WiqlEditor) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case workitems.WiqlValidatedMsg:
+		e.validating = false
+		e.err = msg.Err
+		if msg.Err != nil {
+			return e, nil
+		}
+
+		query := config.Query{Name: adHocQueryName, Wiql: msg.Wiql}
+		return e, tea.Sequence(closeDialog, saveHistory(msg.Wiql), func() tea.Msg { return querySelectedMsg(query) })
+
+	case tea.KeyMsg:
+		if e.naming {
+			return e.updateName(msg)
+		}
+
+		switch msg.String() {
+		case "esc":
+			return e, closeDialog
+		case "ctrl+r":
+			wiql := strings.TrimSpace(e.editor.Value())
+			if wiql == "" || e.validating {
+				return e, nil
+			}
+
+			e.validating = true
+			e.err = nil
+			return e, e.validate(wiql)
+		case "ctrl+s":
+			if strings.TrimSpace(e.editor.Value()) == "" {
+				return e, nil
+			}
+
+			e.naming = true
+			e.editor.Blur()
+			return e, e.name.Focus()
+		case "alt+p":
+			e.recall(e.historyIndex + 1)
+			return e, nil
+		case "alt+n":
+			e.recall(e.historyIndex - 1)
+			return e, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	e.editor, cmd = e.editor.Update(msg)
+
+	return e, cmd
+}
+
+func (e WiqlEditor) updateName(msg tea.KeyMsg) (Dialog, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		e.naming = false
+		e.name.Blur()
+		return e, e.editor.Focus()
+	case "enter":
+		name := strings.TrimSpace(e.name.Value())
+		if name == "" {
+			return e, nil
+		}
+
+		query := config.Query{Name: name, Wiql: strings.TrimSpace(e.editor.Value())}
+		return e, tea.Sequence(closeDialog, func() tea.Msg { return viewSavedMsg(query) })
+	}
+
+	var cmd tea.Cmd
+	e.name, cmd = e.name.Update(msg)
+
+	return e, cmd
+}
+
+// recall replaces the editor content with the history entry at index, -1
+// being the empty editor.
+func (e *WiqlEditor) recall(index int) {
+	if index < -1 || index >= len(e.history) {
+		return
+	}
+
+	e.historyIndex = index
+	if index == -1 {
+		e.editor.SetValue("")
+		return
+	}
+
+	e.editor.SetValue(e.history[index])
+}
+
+func (e WiqlEditor) View() string {
+	lines := []string{"WIQL query", "", e.editor.View(), ""}
+
+	switch {
+	case e.validating:
+		lines = append(lines, helpStyle.Render("Validating…"))
+	case e.err != nil:
+		lines = append(lines, errorStyle.UnsetPadding().Width(80).Render(describeError(e.err)))
+	}
+
+	if e.naming {
+		lines = append(lines, "Save as view: "+e.name.View())
+		lines = append(lines, helpStyle.Render("enter save · esc back"))
+	} else {
+		lines = append(lines, helpStyle.Render("ctrl+r run · alt+p/alt+n history · ctrl+s save as view · esc cancel"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func saveHistory(wiql string) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.AddToHistory(wiql); err != nil {
+			return models.ErrorMsg{Action: "could not save the query history", Err: err}
+		}
+
+		return nil
+	}
+}
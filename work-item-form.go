@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	workitems "lazyaz/internal/work-items"
+	workitemsmodels "lazyaz/internal/work-items/models"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const parentField = "parent"
+
+type workItemSubmittedMsg struct {
+	TypeName string
+	Fields   []workitems.FieldEdit
+	ParentID int
+}
+
+type draftDescriptionMsg struct {
+	Path string
+	Err  error
+}
+
+// WorkItemForm fills a new work item of a given type: the common fields, the
+// fields the type requires and a description written in $EDITOR.
+type WorkItemForm struct {
+	workItemType workitemsmodels.WorkItemType
+	form         Form
+	required     map[string]bool
+	description  string
+	err          error
+}
+
+type typeChosenMsg string
+
+func newTypePicker(types workitems.WorkItemTypesMsg) Picker {
+	var items []PickerItem
+	for _, workItemType := range types {
+		items = append(items, PickerItem{Name: workItemType.Name, Details: workItemType.ReferenceName, Value: workItemType.Name})
+	}
+
+	return NewPicker("New work item", items, 0, func(item PickerItem) tea.Msg {
+		return typeChosenMsg(item.Value.(string))
+	})
+}
+
+func newWorkItemForm(msg workitems.CreationFormMsg) (WorkItemForm, tea.Cmd) {
+	fields := []FormField{
+		NewFormField("Title *", "System.Title", ""),
+		NewFormField("Assigned To", "System.AssignedTo", ""),
+		NewFormField("Area Path", "System.AreaPath", "").WithSuggestions(msg.Areas),
+		NewFormField("Iteration Path", "System.IterationPath", "").WithSuggestions(msg.Iterations),
+		NewFormField("Parent ID", parentField, ""),
+	}
+
+	required := map[string]bool{"System.Title": true}
+
+	for _, field := range msg.Type.Fields {
+		if !field.AlwaysRequired || field.DefaultValue != nil || strings.HasPrefix(field.ReferenceName, "System.") {
+			continue
+		}
+
+		required[field.ReferenceName] = true
+
+		var allowed []string
+		for _, value := range field.AllowedValues {
+			allowed = append(allowed, fmt.Sprint(value))
+		}
+
+		formField := NewFormField(field.Name+" *", field.ReferenceName, "")
+		if len(allowed) > 0 {
+			formField = formField.WithSuggestions(allowed)
+		}
+		fields = append(fields, formField)
+	}
+
+	form, cmd := NewForm(fields...)
+
+	return WorkItemForm{workItemType: msg.Type, form: form, required: required}, cmd
+}
+
+func (f WorkItemForm) Update(msg tea.Msg) (Dialog, tea.Cmd) {
+	switch msg := msg.(type) {
+	case draftDescriptionMsg:
+		if msg.Path != "" {
+			defer os.Remove(msg.Path)
+		}
+
+		f.err = msg.Err
+		if msg.Err == nil {
+			data, err := os.ReadFile(msg.Path)
+			f.description, f.err = string(data), err
+		}
+
+		return f, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return f, closeDialog
+		case "ctrl+e":
+			return f, editInExternalEditor("lazyaz-new-*.md", f.description, func(path string, err error) tea.Msg {
+				return draftDescriptionMsg{Path: path, Err: err}
+			})
+		case "ctrl+s", "enter":
+			submitted, err := f.submit()
+			if err != nil {
+				f.err = err
+				return f, nil
+			}
+
+			return f, tea.Sequence(closeDialog, func() tea.Msg { return submitted })
+		}
+	}
+
+	var cmd tea.Cmd
+	f.form, cmd = f.form.Update(msg)
+
+	return f, cmd
+}
+
+func (f WorkItemForm) submit() (workItemSubmittedMsg, error) {
+	submitted := workItemSubmittedMsg{TypeName: f.workItemType.Name}
+
+	for _, field := range f.form.Fields {
+		value := field.Value()
+
+		if value == "" {
+			if f.required[field.ReferenceName] {
+				return submitted, fmt.Errorf("%s is required", strings.TrimSuffix(field.Label, " *"))
+			}
+			continue
+		}
+
+		if field.ReferenceName == parentField {
+			parentID, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+			if err != nil {
+				return submitted, fmt.Errorf("parent id must be a number")
+			}
+			submitted.ParentID = parentID
+			continue
+		}
+
+		submitted.Fields = append(submitted.Fields, workitems.FieldEdit{ReferenceName: field.ReferenceName, Value: value})
+	}
+
+	if strings.TrimSpace(f.description) != "" {
+		html, err := workitemsmodels.MarkdownToHtml(f.description)
+		if err != nil {
+			return submitted, fmt.Errorf("could not convert the description to HTML: %w", err)
+		}
+
+		submitted.Fields = append(submitted.Fields, workitems.FieldEdit{ReferenceName: "System.Description", Value: html})
+	}
+
+	return submitted, nil
+}
+
+func (f WorkItemForm) View() string {
+	description := "empty"
+	if lines := strings.Count(strings.TrimSpace(f.description), "\n"); strings.TrimSpace(f.description) != "" {
+		description = fmt.Sprintf("%d lines", lines+1)
+	}
+
+	lines := []string{
+		"New " + f.workItemType.Name,
+		"",
+		f.form.View(),
+		lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Description"), description),
+		"",
+	}
+
+	if f.err != nil {
+		lines = append(lines, errorStyle.UnsetPadding().Render(f.err.Error()))
+	}
+
+	lines = append(lines, helpStyle.Render("tab/shift+tab move · → accept suggestion · ctrl+e description · enter create · esc cancel"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}